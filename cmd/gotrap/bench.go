@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"text/tabwriter"
+
+	"go-trap/internal/bench"
+	"go-trap/internal/i18n"
+	"go-trap/registry"
+)
+
+// cmdBench 运行错误写法和正确写法的基准测试，输出两者的比值，
+// 并检查是否达到 registry 中记录的改进
+func cmdBench(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	benchtime := fs.String("benchtime", "", i18n.T("传给 go test 的 -benchtime，如 1s 或 100x"))
+	count := fs.Int("count", 1, i18n.T("每个基准测试运行的次数，结果取平均值"))
+	verbose := fs.Bool("v", false, i18n.T("输出 go test 的原始输出"))
+	fs.Parse(args)
+
+	selected, err := selectTraps(fs.Args())
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	total, failed, unchecked := 0, 0, 0
+	for _, t := range selected {
+		if len(t.Benches) == 0 {
+			continue
+		}
+		var funcs []string
+		for _, p := range t.Benches {
+			funcs = append(funcs, p.Wrong, p.Correct)
+		}
+		i18n.Printf("=== %s（%s）\n", t.ID, i18n.T(t.Title))
+		out, err := bench.Run(ctx, t.Package(), funcs, bench.Options{
+			Dir:       root,
+			Benchtime: *benchtime,
+			Count:     *count,
+		})
+		if err != nil {
+			return err
+		}
+		if *verbose {
+			os.Stdout.Write(out)
+		}
+
+		results := bench.Parse(out)
+		for _, p := range t.Benches {
+			total++
+			wrong, ok1 := results[p.Wrong]
+			correct, ok2 := results[p.Correct]
+			if !ok1 || !ok2 {
+				failed++
+				i18n.Printf("FAIL  %s / %s: 没有找到基准测试 Benchmark%s 或 Benchmark%s\n\n", p.Wrong, p.Correct, p.Wrong, p.Correct)
+				continue
+			}
+			ratio := bench.Ratio(wrong, correct, p.Metric)
+			switch {
+			case p.MinRatio == 0:
+				unchecked++
+				i18n.Printf("INFO  %s / %s: %s 比值 %s（不检查）\n", p.Wrong, p.Correct, p.Metric, formatRatio(ratio))
+			case ratio < p.MinRatio:
+				failed++
+				i18n.Printf("FAIL  %s / %s: %s 比值 %s，没有达到期望的 %gx\n", p.Wrong, p.Correct, p.Metric, formatRatio(ratio), p.MinRatio)
+			default:
+				i18n.Printf("PASS  %s / %s: %s 比值 %s（期望 >= %gx）\n", p.Wrong, p.Correct, p.Metric, formatRatio(ratio), p.MinRatio)
+			}
+			printBenchTable(wrong, correct)
+			fmt.Println()
+		}
+	}
+	if total == 0 {
+		i18n.Println("选中的示例中没有基准测试")
+		return nil
+	}
+
+	i18n.Printf("共 %d 组对比，达到预期 %d 组，未达到 %d 组，只报告比值 %d 组\n", total, total-failed-unchecked, failed, unchecked)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+// printBenchTable 输出一组对比的三项指标及比值
+func printBenchTable(wrong, correct bench.Result) {
+	metrics := []registry.BenchMetric{registry.NsPerOp, registry.BytesPerOp, registry.AllocsPerOp}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "\t")
+	for _, m := range metrics {
+		fmt.Fprintf(w, "%s\t", m)
+	}
+	fmt.Fprintln(w)
+	for _, r := range []bench.Result{wrong, correct} {
+		fmt.Fprintf(w, "  %s\t", r.Name)
+		for _, m := range metrics {
+			fmt.Fprintf(w, "%.0f\t", r.Metric(m))
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprint(w, "  "+i18n.T("比值")+"\t")
+	for _, m := range metrics {
+		fmt.Fprintf(w, "%s\t", formatRatio(bench.Ratio(wrong, correct, m)))
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+func formatRatio(r float64) string {
+	if math.IsInf(r, 1) {
+		return "∞"
+	}
+	return fmt.Sprintf("%.2fx", r)
+}
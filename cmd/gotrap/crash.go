@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/runner"
+	"go-trap/registry"
+)
+
+// cmdCrash 在子进程中单独运行会崩溃的错误示例，并检查崩溃结果是否符合 registry 中的记录
+func cmdCrash(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("crash", flag.ExitOnError)
+	verbose := fs.Bool("v", false, i18n.T("输出完整的崩溃信息和 goroutine 栈"))
+	parallel := fs.Int("parallel", runtime.NumCPU(), i18n.T("同时运行的示例数，1 表示依次运行"))
+	timeout := fs.Duration("timeout", 10*time.Second, i18n.T("单个示例的运行超时"))
+	fs.Parse(args)
+
+	selected, err := selectTraps(fs.Args())
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	var jobs []runner.Job
+	var crashes []registry.Crash
+	for _, t := range selected {
+		for _, c := range t.Crashes {
+			jobs = append(jobs, runner.Job{
+				ID:     t.ID + "." + c.Func,
+				Target: t.Main(),
+				Args:   langArgs(i18n.Current(), "-run", c.Func),
+			})
+			crashes = append(crashes, c)
+		}
+	}
+	if len(jobs) == 0 {
+		i18n.Println("选中的示例中没有会崩溃的错误示例")
+		return nil
+	}
+
+	results, err := runner.Run(ctx, jobs, runner.Options{
+		Dir:      root,
+		Parallel: *parallel,
+		Timeout:  *timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for i, res := range results {
+		f := res.Failure()
+		problem := checkCrash(crashes[i], res, f)
+		if problem != "" {
+			failed++
+			fmt.Printf("FAIL  %s: %s\n", res.ID, problem)
+		} else {
+			fmt.Printf("PASS  %s: %s: %s\n", res.ID, crashLabel(f.Kind), f.Message)
+		}
+		if *verbose || problem != "" {
+			fmt.Println(indent(string(res.Stderr)))
+		}
+	}
+
+	i18n.Printf("共 %d 个崩溃示例，符合预期 %d 个，不符合 %d 个\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+// checkCrash 比较实际的崩溃结果和期望，符合时返回空字符串
+func checkCrash(want registry.Crash, res *runner.Result, f *runner.Failure) string {
+	switch {
+	case res.BuildErr != nil:
+		return i18n.T("编译失败")
+	case res.TimedOut:
+		return i18n.T("超时，没有按预期崩溃")
+	case f == nil:
+		return i18n.Sprintf("没有崩溃（退出码 %d），期望 %s: %s", res.ExitCode, crashLabel(string(want.Kind)), want.Message)
+	case f.Kind != string(want.Kind):
+		return i18n.Sprintf("崩溃方式是 %s，期望 %s", f.Kind, want.Kind)
+	case !strings.Contains(f.Message, want.Message):
+		return i18n.Sprintf("崩溃信息是 %q，期望包含 %q", f.Message, want.Message)
+	case res.ExitCode != want.ExitCode:
+		return i18n.Sprintf("退出码是 %d，期望 %d", res.ExitCode, want.ExitCode)
+	}
+	return ""
+}
+
+func crashLabel(kind string) string {
+	if kind == string(registry.Fatal) {
+		return "fatal error"
+	}
+	return kind
+}
+
+func indent(s string) string {
+	s = strings.TrimRight(s, "\n")
+	return "    " + strings.ReplaceAll(s, "\n", "\n    ")
+}
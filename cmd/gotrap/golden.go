@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"runtime"
+	"time"
+
+	"go-trap/internal/golden"
+	"go-trap/internal/i18n"
+	"go-trap/internal/runner"
+)
+
+// cmdGolden 以每种语言运行示例，并把输出与 testdata/golden/<语言> 下的记录比较，-update 时重新记录
+func cmdGolden(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("golden", flag.ExitOnError)
+	update := fs.Bool("update", false, i18n.T("用本次的输出重写 golden 文件"))
+	parallel := fs.Int("parallel", runtime.NumCPU(), i18n.T("同时运行的示例数，1 表示依次运行"))
+	timeout := fs.Duration("timeout", 30*time.Second, i18n.T("单个示例的运行超时"))
+	fs.Parse(args)
+
+	selected, err := selectTraps(fs.Args())
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	var jobs []runner.Job
+	for _, lang := range i18n.Langs() {
+		for _, t := range selected {
+			jobs = append(jobs, runner.Job{ID: t.ID, Target: t.Main(), Args: langArgs(lang)})
+		}
+	}
+	results, err := runner.Run(ctx, jobs, runner.Options{
+		Dir:      root,
+		Parallel: *parallel,
+		Timeout:  *timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for i, res := range results {
+		t := selected[i%len(selected)]
+		lang := i18n.Langs()[i/len(selected)]
+		name := string(lang) + "/" + t.ID
+		if !res.Passed() {
+			i18n.Printf("FAIL  %s: %s\n%s\n", name, res.Status(), res.Stderr)
+			failed++
+			continue
+		}
+		got, err := golden.Normalize(lang, res.Stdout, t.Output)
+		if err != nil {
+			i18n.Printf("FAIL  %s: %v\n", name, err)
+			failed++
+			continue
+		}
+
+		if *update {
+			if err := golden.Write(root, lang, t.ID, got); err != nil {
+				return err
+			}
+			i18n.Printf("已更新  %s\n", golden.Path(root, lang, t.ID))
+			continue
+		}
+
+		want, err := golden.Read(root, lang, t.ID)
+		if errors.Is(err, os.ErrNotExist) {
+			i18n.Printf("FAIL  %s: 没有 golden 文件，请先运行 gotrap golden -update %s\n", name, t.ID)
+			failed++
+			continue
+		} else if err != nil {
+			return err
+		}
+		if diff := golden.Diff(want, got); diff != "" {
+			i18n.Printf("FAIL  %s: 输出与 golden 文件不一致\n%s\n", name, diff)
+			failed++
+			continue
+		}
+		i18n.Printf("PASS  %s\n", name)
+	}
+
+	i18n.Printf("共 %d 个示例，通过 %d 个，失败 %d 个\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// gotrap 是 examples 目录下所有 Go 陷阱示例的命令行入口。
+//
+// 每个示例都是 examples/cmd 下的一个命令，gotrap 从 registry 读取示例列表，
+// 编译后在子进程中运行它们。
+//
+// 用法：
+//
+//	gotrap [-lang zh|en] <子命令> ...
+//	gotrap list [-category 分类]
+//	gotrap run [-parallel N] [-timeout 时长] [-format text|json|junit] [-o 文件] [示例ID|分类 ...]
+//	gotrap show <示例ID>
+//	gotrap golden [-update] [示例ID|分类 ...]
+//	gotrap crash [-v] [示例ID|分类 ...]
+//	gotrap race [-v] [示例ID|分类 ...]
+//	gotrap bench [-benchtime 时长] [-count N] [示例ID|分类 ...]
+//	gotrap vet [-v] [示例ID|分类 ...]
+//	gotrap readme [-check]
+//
+// run 在有示例失败（编译失败、非零退出或超时）时以退出码 1 结束，
+// 可以直接用于 CI 和入门脚本。-format json 为每个示例输出一个 JSON 对象，
+// -format junit 输出 JUnit XML，便于导入仪表盘和测试报告工具。
+//
+// -lang 选择 gotrap 和示例输出的语言，默认按 LC_ALL、LC_MESSAGES、LANG 环境变量选择，
+// 都没有设置时是中文。golden 总是检查所有语言的输出。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/readme"
+	"go-trap/internal/runner"
+	"go-trap/registry"
+)
+
+func main() {
+	flag.Usage = usage
+	lang := flag.String("lang", string(i18n.Current()), i18n.T("输出语言：zh 或 en，默认由 LANG 等环境变量决定"))
+	flag.Parse()
+	l, err := i18n.Parse(*lang)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gotrap: %v\n", err)
+		os.Exit(2)
+	}
+	i18n.SetLang(l)
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	switch cmd {
+	case "list":
+		err = cmdList(args)
+	case "run":
+		err = cmdRun(ctx, args)
+	case "show":
+		err = cmdShow(args)
+	case "golden":
+		err = cmdGolden(ctx, args)
+	case "crash":
+		err = cmdCrash(ctx, args)
+	case "race":
+		err = cmdRace(ctx, args)
+	case "bench":
+		err = cmdBench(ctx, args)
+	case "vet":
+		err = cmdVet(args)
+	case "readme":
+		err = cmdReadme(args)
+	default:
+		fmt.Fprintf(os.Stderr, i18n.T("gotrap: 未知的子命令 %q\n"), cmd)
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		if !errors.Is(err, errFailed) {
+			fmt.Fprintf(os.Stderr, "gotrap: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, i18n.T(usageText))
+}
+
+const usageText = `用法：
+  gotrap [-lang zh|en] <子命令> ...
+
+  gotrap list [-category 分类]
+  gotrap run [-parallel N] [-timeout 时长] [-format text|json|junit] [-o 文件] [示例ID|分类 ...]
+  gotrap show <示例ID>
+  gotrap golden [-update] [-parallel N] [-timeout 时长] [示例ID|分类 ...]
+  gotrap crash [-v] [-parallel N] [-timeout 时长] [示例ID|分类 ...]
+  gotrap race [-v] [-parallel N] [-timeout 时长] [示例ID|分类 ...]
+  gotrap bench [-v] [-benchtime 时长] [-count N] [示例ID|分类 ...]
+  gotrap vet [-v] [示例ID|分类 ...]
+  gotrap readme [-check]
+
+分类：goroutine、pointer、interface、channel、other
+`
+
+// errFailed 表示有示例运行失败，详细信息已经输出
+var errFailed = errors.New("有示例运行失败")
+
+func cmdList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	category := fs.String("category", "", i18n.T("只列出指定分类的示例"))
+	fs.Parse(args)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("ID\t分类\t严重程度\t标题"))
+	for _, t := range registry.All() {
+		if *category != "" && string(t.Category) != *category {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.ID, t.Category, t.Severity, i18n.T(t.Title))
+	}
+	return w.Flush()
+}
+
+func cmdRun(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	parallel := fs.Int("parallel", runtime.NumCPU(), i18n.T("同时运行的示例数，1 表示依次运行"))
+	timeout := fs.Duration("timeout", 30*time.Second, i18n.T("单个示例的运行超时"))
+	format := fs.String("format", "text", i18n.T("输出格式：text、json 或 junit"))
+	output := fs.String("o", "", i18n.T("把 json 或 junit 结果写入文件而不是标准输出"))
+	fs.Parse(args)
+
+	switch *format {
+	case "text", "json", "junit":
+	default:
+		return i18n.Errorf("未知的输出格式 %q，可选 text、json、junit", *format)
+	}
+	if *output != "" && *format == "text" {
+		return errors.New(i18n.T("-o 只能和 -format json 或 -format junit 一起使用"))
+	}
+
+	selected, err := selectTraps(fs.Args())
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	results, err := runTraps(ctx, root, selected, i18n.Current(), runner.Options{
+		Parallel: *parallel,
+		Timeout:  *timeout,
+	})
+	if err != nil {
+		return err
+	}
+	if *format != "text" {
+		return writeReport(*format, *output, selected, results)
+	}
+
+	for i, res := range results {
+		fmt.Println("----------------------------------------")
+		i18n.Printf("运行: %s\n", selected[i].Main())
+		fmt.Println("----------------------------------------")
+		os.Stdout.Write(res.Stdout)
+		os.Stdout.Write(res.Stderr)
+		fmt.Println()
+	}
+	return summarize(results)
+}
+
+// runTraps 在仓库根目录 root 下编译并运行 traps 对应的示例命令，示例以语言 lang 输出
+func runTraps(ctx context.Context, root string, traps []registry.Trap, lang i18n.Lang, opts runner.Options) ([]*runner.Result, error) {
+	jobs := make([]runner.Job, len(traps))
+	for i, t := range traps {
+		jobs[i] = runner.Job{ID: t.ID, Target: t.Main(), Args: langArgs(lang)}
+	}
+	opts.Dir = root
+	return runner.Run(ctx, jobs, opts)
+}
+
+// langArgs 返回让示例以语言 lang 输出的参数，后面追加 args
+func langArgs(lang i18n.Lang, args ...string) []string {
+	return append([]string{"-lang", string(lang)}, args...)
+}
+
+// summarize 输出通过/失败汇总，有失败时返回 errFailed
+func summarize(results []*runner.Result) error {
+	fmt.Println("==========================================")
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	failed := 0
+	for _, res := range results {
+		mark := "PASS"
+		if !res.Passed() {
+			mark = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\n", mark, res.ID, res.Status(), res.Duration.Round(time.Millisecond))
+	}
+	w.Flush()
+	i18n.Printf("共 %d 个示例，通过 %d 个，失败 %d 个\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+func cmdShow(args []string) error {
+	if len(args) != 1 {
+		return errors.New(i18n.T("用法：gotrap show <示例ID>"))
+	}
+	t, ok := registry.Lookup(args[0])
+	if !ok {
+		return i18n.Errorf("未知的示例: %s", args[0])
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	src, err := os.ReadFile(filepath.Join(root, t.Source))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("ID:       %s\n", t.ID)
+	i18n.Printf("标题:     %s\n", i18n.T(t.Title))
+	i18n.Printf("问题:     %s\n", i18n.T(t.Problem))
+	i18n.Printf("分类:     %s\n", t.Category)
+	i18n.Printf("严重程度: %s\n", t.Severity)
+	i18n.Printf("Go 版本:  %s\n", t.GoVersions)
+	i18n.Printf("错误写法: %s\n", strings.Join(t.Wrong, ", "))
+	i18n.Printf("正确写法: %s\n", strings.Join(t.Correct, ", "))
+	i18n.Printf("文档:     %s\n", readme.DocURL(t, i18n.Current()))
+	i18n.Printf("包:       %s\n", t.Package())
+	i18n.Printf("文件:     %s\n", t.Source)
+	i18n.Printf("运行:     go run %s\n", t.Main())
+	fmt.Println(strings.Repeat("-", 40))
+	_, err = os.Stdout.Write(src)
+	return err
+}
+
+// moduleRoot 从当前目录向上查找 go.mod 所在的目录
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New(i18n.T("找不到 go.mod，请在仓库目录下运行"))
+		}
+		dir = parent
+	}
+}
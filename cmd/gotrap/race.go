@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/race"
+	"go-trap/internal/runner"
+	"go-trap/registry"
+)
+
+// cmdRace 用竞态检测器编译并发示例，检查错误写法确实存在数据竞争而正确写法没有
+func cmdRace(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("race", flag.ExitOnError)
+	verbose := fs.Bool("v", false, i18n.T("输出每个数据竞争报告的访问栈和 goroutine 创建位置"))
+	parallel := fs.Int("parallel", runtime.NumCPU(), i18n.T("同时运行的示例数，1 表示依次运行"))
+	timeout := fs.Duration("timeout", 30*time.Second, i18n.T("单个示例的运行超时"))
+	fs.Parse(args)
+
+	selected, err := selectTraps(fs.Args())
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	var jobs []runner.Job
+	for _, t := range selected {
+		for _, c := range t.Races {
+			jobs = append(jobs, runner.Job{ID: t.ID, Target: t.Main(), Args: langArgs(i18n.Current(), "-run", c.Func)})
+		}
+	}
+	if len(jobs) == 0 {
+		i18n.Println("选中的示例中没有需要竞态检测的函数")
+		return nil
+	}
+
+	results, err := runner.Run(ctx, jobs, runner.Options{
+		Dir:      root,
+		Parallel: *parallel,
+		Timeout:  *timeout,
+		Race:     true,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed, i := 0, 0
+	for _, t := range selected {
+		if len(t.Races) == 0 {
+			continue
+		}
+		i18n.Printf("%s（%s）\n", t.ID, i18n.T(t.Title))
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, i18n.T("\t函数\t期望\t结果\t报告数\t位置"))
+		var details []string
+		for _, c := range t.Races {
+			res := results[i]
+			i++
+			reports := race.Parse(res.Stderr)
+
+			got := describeRace(len(reports) > 0)
+			// 除了检测到竞争时的退出码 66，其他非零退出（panic、fatal error 等）都说明示例没有正常运行完
+			abnormal := res.BuildErr == nil && !res.TimedOut && res.ExitCode != 0 &&
+				!(res.ExitCode == race.ExitCode && len(reports) > 0)
+			ok := res.BuildErr == nil && !res.TimedOut && !abnormal && (len(reports) > 0) == c.Racy
+			switch {
+			case res.BuildErr != nil:
+				got = i18n.T("编译失败")
+			case res.TimedOut:
+				got = i18n.T("超时")
+			case abnormal:
+				got = i18n.Sprintf("异常退出（退出码 %d）", res.ExitCode)
+			}
+			mark := "PASS"
+			if !ok {
+				mark = "FAIL"
+				failed++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				mark, c.Func, describeRace(c.Racy), got, len(reports), raceLocations(root, reports))
+
+			if *verbose {
+				for n, r := range reports {
+					details = append(details, formatReport(root, c.Func, n+1, r))
+				}
+			}
+			if res.BuildErr != nil || abnormal {
+				details = append(details, indent(string(res.Stderr)))
+			}
+		}
+		w.Flush()
+		for _, d := range details {
+			fmt.Println(d)
+		}
+		fmt.Println()
+	}
+
+	i18n.Printf("共 %d 个函数，符合预期 %d 个，不符合 %d 个\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+func describeRace(racy bool) string {
+	if racy {
+		return i18n.T("有竞争")
+	}
+	return i18n.T("无竞争")
+}
+
+// raceLocations 汇总每个报告中两次访问在示例代码中的位置
+func raceLocations(root string, reports []race.Report) string {
+	seen := make(map[string]bool)
+	var locs []string
+	for _, r := range reports {
+		var pair []string
+		for _, a := range r.Accesses {
+			if f, ok := race.UserFrame(a.Stack, registry.Module+"/"); ok {
+				pair = append(pair, a.Op+" "+relPath(root, f))
+			}
+		}
+		loc := strings.Join(pair, " / ")
+		if loc != "" && !seen[loc] {
+			seen[loc] = true
+			locs = append(locs, loc)
+		}
+	}
+	return strings.Join(locs, "; ")
+}
+
+// formatReport 以缩进的形式输出一个数据竞争报告
+func formatReport(root, fn string, n int, r race.Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "    "+i18n.T("%s 的第 %d 个数据竞争：")+"\n", fn, n)
+	for _, a := range r.Accesses {
+		prefix := ""
+		if a.Previous {
+			prefix = i18n.T("之前的 ")
+		}
+		fmt.Fprintf(&b, "      "+i18n.T("%s%s（goroutine %d，地址 %s）")+"\n", prefix, a.Op, a.Goroutine, a.Addr)
+		for _, f := range a.Stack {
+			fmt.Fprintf(&b, "        %s  %s\n", f.Func, relPath(root, f))
+		}
+	}
+	for _, g := range r.Goroutines {
+		fmt.Fprintf(&b, "      "+i18n.T("goroutine %d（%s）创建于：")+"\n", g.ID, g.State)
+		for _, f := range g.CreatedAt {
+			fmt.Fprintf(&b, "        %s  %s\n", f.Func, relPath(root, f))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// relPath 把帧中的绝对路径转换为相对仓库根目录的路径
+func relPath(root string, f race.Frame) string {
+	if rel, err := filepath.Rel(root, f.File); err == nil && !strings.HasPrefix(rel, "..") {
+		return fmt.Sprintf("%s:%d", rel, f.Line)
+	}
+	return f.String()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-trap/internal/golden"
+	"go-trap/internal/i18n"
+	"go-trap/internal/readme"
+)
+
+// cmdReadme 根据 registry 和示例源码重新生成每种语言的 README，
+// -check 时只比较，有 README 与生成结果不一致时失败
+func cmdReadme(args []string) error {
+	fs := flag.NewFlagSet("readme", flag.ExitOnError)
+	check := fs.Bool("check", false, i18n.T("只检查 README 是否是最新的，不写入"))
+	fs.Parse(args)
+
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	stale := false
+	for _, lang := range i18n.Langs() {
+		content, err := readme.Generate(root, lang)
+		if err != nil {
+			return err
+		}
+		name := readme.File(lang)
+		path := filepath.Join(root, name)
+
+		if !*check {
+			if err := os.WriteFile(path, content, 0o644); err != nil {
+				return err
+			}
+			i18n.Printf("已生成  %s\n", path)
+			continue
+		}
+
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(current, content) {
+			i18n.Printf("%s 是最新的\n", name)
+			continue
+		}
+		stale = true
+		i18n.Printf("%s 与生成结果不一致（- 是当前文件，+ 是生成结果），请运行 go run ./cmd/gotrap readme：\n", name)
+		fmt.Println(indent(golden.Diff(string(current), string(content))))
+	}
+	if stale {
+		return errFailed
+	}
+	return nil
+}
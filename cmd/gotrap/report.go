@@ -0,0 +1,57 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/report"
+	"go-trap/internal/runner"
+	"go-trap/registry"
+)
+
+// writeReport 以 json 或 junit 格式输出运行结果，path 为空时写到标准输出。
+// 有示例失败时返回 errFailed，与文本输出的退出码保持一致。
+func writeReport(format, path string, traps []registry.Trap, results []*runner.Result) error {
+	entries := make([]report.Entry, len(results))
+	failed := 0
+	for i, res := range results {
+		entries[i] = report.NewEntry(traps[i], res)
+		if !res.Passed() {
+			failed++
+		}
+	}
+
+	if path == "" {
+		if err := encodeReport(os.Stdout, format, entries); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = encodeReport(f, format, entries)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if path != "" {
+		i18n.Printf("共 %d 个示例，通过 %d 个，失败 %d 个，结果已写入 %s\n", len(results), len(results)-failed, failed, path)
+	}
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+func encodeReport(w io.Writer, format string, entries []report.Entry) error {
+	if format == "junit" {
+		return report.WriteJUnit(w, entries)
+	}
+	return report.WriteJSON(w, entries)
+}
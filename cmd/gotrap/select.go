@@ -0,0 +1,33 @@
+package main
+
+import (
+	"go-trap/internal/i18n"
+	"go-trap/registry"
+)
+
+// selectTraps 根据参数（陷阱 ID 或分类名）筛选陷阱，参数为空时返回全部
+func selectTraps(args []string) ([]registry.Trap, error) {
+	all := registry.All()
+	if len(args) == 0 {
+		return all, nil
+	}
+
+	seen := make(map[string]bool)
+	var selected []registry.Trap
+	for _, arg := range args {
+		matched := false
+		for _, t := range all {
+			if t.ID == arg || string(t.Category) == arg {
+				matched = true
+				if !seen[t.ID] {
+					seen[t.ID] = true
+					selected = append(selected, t)
+				}
+			}
+		}
+		if !matched {
+			return nil, i18n.Errorf("未知的示例或分类: %s", arg)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/trapvet"
+)
+
+// cmdVet 用 trapvet 的分析器检查示例代码，确认错误写法被报告而正确写法没有
+func cmdVet(args []string) error {
+	fs := flag.NewFlagSet("vet", flag.ExitOnError)
+	verbose := fs.Bool("v", false, i18n.T("输出每条诊断信息"))
+	fs.Parse(args)
+
+	selected, err := selectTraps(fs.Args())
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+
+	analyzers := make(map[string]*analysis.Analyzer)
+	for _, a := range trapvet.Analyzers() {
+		analyzers[a.Name] = a
+	}
+	var run []*analysis.Analyzer
+	var patterns []string
+	for _, t := range selected {
+		if len(t.Vet) == 0 {
+			continue
+		}
+		a, ok := analyzers[t.ID]
+		if !ok {
+			return i18n.Errorf("trapvet 中没有陷阱 %s 的分析器", t.ID)
+		}
+		run = append(run, a)
+		patterns = append(patterns, t.Package())
+	}
+	if len(run) == 0 {
+		i18n.Println("选中的示例中没有需要 trapvet 检查的函数")
+		return nil
+	}
+
+	diags, err := vetPackages(root, run, patterns)
+	if err != nil {
+		return err
+	}
+
+	total, failed := 0, 0
+	for _, t := range selected {
+		if len(t.Vet) == 0 {
+			continue
+		}
+		i18n.Printf("%s（%s）\n", t.ID, i18n.T(t.Title))
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, i18n.T("\t函数\t期望\t结果\t位置"))
+		var details []string
+		for _, c := range t.Vet {
+			total++
+			found := diags[vetKey{t.ID, t.Package(), c.Func}]
+			mark := "PASS"
+			if (len(found) > 0) != c.Flagged || c.Flagged && !hasCategory(found, c.Category) {
+				mark = "FAIL"
+				failed++
+			}
+			locs := make([]string, 0, len(found))
+			for _, d := range found {
+				loc := d.pos
+				if d.category != t.ID {
+					loc += " " + d.category
+				}
+				locs = append(locs, loc)
+				if *verbose {
+					details = append(details, "    "+d.pos+": "+d.message)
+				}
+			}
+			want := describeVet(c.Flagged)
+			if c.Flagged && c.Category != "" {
+				want += " " + c.Category
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				mark, c.Func, want, describeVet(len(found) > 0), strings.Join(locs, "; "))
+		}
+		w.Flush()
+		for _, d := range details {
+			fmt.Println(d)
+		}
+		fmt.Println()
+	}
+
+	i18n.Printf("共 %d 个函数，符合预期 %d 个，不符合 %d 个\n", total, total-failed, failed)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+// vetKey 标识一个分析器在一个包中的一个函数上报告的诊断
+type vetKey struct {
+	analyzer, pkg, fn string
+}
+
+// vetDiag 是一条诊断信息，pos 是相对仓库根目录的位置
+type vetDiag struct {
+	pos, category, message string
+}
+
+// hasCategory 报告 diags 中是否有类别为 category 的诊断，category 为空时总是成立
+func hasCategory(diags []vetDiag, category string) bool {
+	if category == "" {
+		return true
+	}
+	for _, d := range diags {
+		if d.category == category {
+			return true
+		}
+	}
+	return false
+}
+
+// vetPackages 加载 patterns 中的包并运行分析器，按分析器、包和所在函数汇总诊断信息
+func vetPackages(root string, analyzers []*analysis.Analyzer, patterns []string) (map[vetKey][]vetDiag, error) {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: root}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, i18n.Errorf("加载示例包失败")
+	}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	diags := make(map[vetKey][]vetDiag)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, act.Err
+		}
+		for _, d := range act.Diagnostics {
+			fn := enclosingFunc(act.Package.Syntax, d.Pos)
+			key := vetKey{act.Analyzer.Name, act.Package.PkgPath, fn}
+			pos := act.Package.Fset.Position(d.Pos)
+			if rel, err := filepath.Rel(root, pos.Filename); err == nil {
+				pos.Filename = rel
+			}
+			diags[key] = append(diags[key], vetDiag{
+				pos:      fmt.Sprintf("%s:%d", pos.Filename, pos.Line),
+				category: d.Category,
+				message:  d.Message,
+			})
+		}
+	}
+	return diags, nil
+}
+
+// enclosingFunc 返回 pos 所在的函数声明的名称，方法写作 "Type.Method"
+func enclosingFunc(files []*ast.File, pos token.Pos) string {
+	for _, f := range files {
+		if pos < f.FileStart || pos >= f.FileEnd {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || pos < fd.Pos() || pos >= fd.End() {
+				continue
+			}
+			if fd.Recv == nil || len(fd.Recv.List) == 0 {
+				return fd.Name.Name
+			}
+			typ := fd.Recv.List[0].Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if id, ok := typ.(*ast.Ident); ok {
+				return id.Name + "." + fd.Name.Name
+			}
+			return fd.Name.Name
+		}
+	}
+	return ""
+}
+
+func describeVet(flagged bool) string {
+	if flagged {
+		return i18n.T("报告")
+	}
+	return i18n.T("不报告")
+}
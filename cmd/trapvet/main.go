@@ -0,0 +1,26 @@
+// trapvet 用静态分析检查代码中是否有 examples 所演示的陷阱。
+//
+// 每个陷阱对应一个分析器，分析器以陷阱 ID 命名，诊断信息中带有陷阱 ID 和 README 中对应章节的链接。
+// trapvet 既可以直接运行，也可以作为 go vet 的分析工具：
+//
+//	trapvet ./...
+//	trapvet -json ./...
+//	trapvet -defer_order ./...        # 只运行指定的分析器
+//	trapvet -fix ./...                # 应用建议的修复
+//	go vet -vettool=$(which trapvet) ./...
+//
+// 直接运行时由 golang.org/x/tools 加载包，它只能读取 go.mod 中固定的 x/tools 版本所支持的 Go 工具链
+// 生成的导出数据；用更新的工具链时直接运行会在加载包时失败，这时改用 go vet -vettool，由 go 命令加载包。
+//
+// 诊断信息的语言按 LC_ALL、LC_MESSAGES、LANG 环境变量选择，默认是中文。
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"go-trap/internal/trapvet"
+)
+
+func main() {
+	multichecker.Main(trapvet.Analyzers()...)
+}
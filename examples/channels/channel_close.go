@@ -1,42 +1,46 @@
-package main
+package channels
 
 import (
-	"fmt"
 	"time"
+
+	"go-trap/internal/i18n"
 )
 
 // 陷阱：未关闭通道导致泄漏
 // 问题：通道未正确关闭，导致接收方永远阻塞
 
-func main() {
-	fmt.Println("=== 陷阱示例：未关闭通道导致泄漏 ===")
-	
+// CloseDemo 依次运行“未关闭通道导致泄漏”的各个示例
+func CloseDemo() {
+	i18n.Println("=== 陷阱示例：未关闭通道导致泄漏 ===")
+
 	// 错误示例：通道未关闭
-	fmt.Println("\n错误示例：")
-	wrongWay()
-	
+	i18n.Println("\n错误示例：")
+	CloseWrongWay()
+
 	time.Sleep(200 * time.Millisecond)
-	
+
 	// 正确示例：正确关闭通道
-	fmt.Println("\n正确示例：")
-	correctWay()
-	
+	i18n.Println("\n正确示例：")
+	CloseCorrectWay()
+
 	time.Sleep(200 * time.Millisecond)
 }
 
 // 错误方式：通道未关闭，接收方可能永远阻塞
-func wrongWay() {
+//
+//readme:wrong
+func CloseWrongWay() {
 	ch := make(chan int)
-	
+
 	// 发送方
 	go func() {
 		for i := 0; i < 3; i++ {
 			ch <- i
-			fmt.Printf("发送: %d\n", i)
+			i18n.Printf("发送: %d\n", i)
 		}
 		// 忘记关闭通道！
 	}()
-	
+
 	// 接收方会一直等待
 	go func() {
 		for {
@@ -44,79 +48,81 @@ func wrongWay() {
 			if !ok {
 				break
 			}
-			fmt.Printf("接收: %d\n", val)
+			i18n.Printf("接收: %d\n", val)
 		}
-		fmt.Println("接收完成")
+		i18n.Println("接收完成")
 	}()
-	
+
 	time.Sleep(100 * time.Millisecond)
-	fmt.Println("主程序退出（接收方可能还在等待）")
+	i18n.Println("主程序退出（接收方可能还在等待）")
 }
 
 // 正确方式：发送方关闭通道
-func correctWay() {
+//
+//readme:correct
+func CloseCorrectWay() {
 	ch := make(chan int)
-	
+
 	// 发送方
 	go func() {
 		defer close(ch) // 确保通道被关闭
 		for i := 0; i < 3; i++ {
 			ch <- i
-			fmt.Printf("发送: %d\n", i)
+			i18n.Printf("发送: %d\n", i)
 		}
 	}()
-	
+
 	// 接收方
 	go func() {
 		for val := range ch { // range 会在通道关闭时自动退出
-			fmt.Printf("接收: %d\n", val)
+			i18n.Printf("接收: %d\n", val)
 		}
-		fmt.Println("接收完成")
+		i18n.Println("接收完成")
 	}()
-	
+
 	time.Sleep(100 * time.Millisecond)
-	fmt.Println("所有操作完成")
+	i18n.Println("所有操作完成")
 }
 
 // 正确方式2：使用 context 控制
-func correctWay2() {
+func CloseCorrectWay2() {
 	ch := make(chan int)
 	done := make(chan bool)
-	
+
 	// 发送方
 	go func() {
 		for i := 0; i < 3; i++ {
 			select {
 			case ch <- i:
-				fmt.Printf("发送: %d\n", i)
+				i18n.Printf("发送: %d\n", i)
 			case <-done:
 				return
 			}
 		}
 		close(ch)
 	}()
-	
+
 	// 接收方
 	go func() {
 		for val := range ch {
-			fmt.Printf("接收: %d\n", val)
+			i18n.Printf("接收: %d\n", val)
 		}
 		done <- true
 	}()
-	
+
 	time.Sleep(100 * time.Millisecond)
 }
 
 // 最佳实践：谁创建通道，谁负责关闭
-func bestPractice() {
+func CloseBestPractice() {
 	// 生产者函数创建并返回通道
-	ch := producer()
-	
+	ch := Producer()
+
 	// 消费者从通道读取
-	consumer(ch)
+	Consumer(ch)
 }
 
-func producer() <-chan int {
+func Producer() <-chan int {
 	ch := make(chan int)
 	go func() {
 		defer close(ch) // 创建者负责关闭
@@ -127,9 +133,8 @@ func producer() <-chan int {
 	return ch
 }
 
-func consumer(ch <-chan int) {
+func Consumer(ch <-chan int) {
 	for val := range ch {
-		fmt.Printf("消费: %d\n", val)
+		i18n.Printf("消费: %d\n", val)
 	}
 }
-
@@ -0,0 +1,264 @@
+package channels
+
+import (
+	"time"
+
+	"go-trap/internal/i18n"
+)
+
+// 陷阱：Select 的 Default Case
+// 问题：select 语句中的 default case 可能导致非阻塞行为，影响程序逻辑
+
+// SelectDefaultDemo 依次运行“Select 的 Default Case”的各个示例
+func SelectDefaultDemo() {
+	i18n.Println("=== 陷阱示例：Select 的 Default Case ===")
+
+	// 陷阱1：default case 导致非阻塞
+	i18n.Println("\n陷阱1：default case 导致非阻塞")
+	SelectDefaultTrap1()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// 陷阱2：for 循环中的 select default 空转
+	i18n.Println("\n陷阱2：for 循环中的 select default 空转")
+	printCPUTime(SelectDefaultTrap2)
+
+	// 正确方式：去掉 default，阻塞等待
+	i18n.Println("\n正确方式：去掉 default，阻塞等待")
+	printCPUTime(SelectDefaultCorrectWay4)
+
+	// 正确方式：理解 default 的用途
+	i18n.Println("\n正确方式：使用 default 实现超时")
+	SelectDefaultCorrectWay()
+
+	time.Sleep(200 * time.Millisecond)
+}
+
+// 陷阱：default case 导致立即返回，可能错过数据
+//
+//readme:wrong
+func SelectDefaultTrap1() {
+	ch := make(chan int)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ch <- 42
+	}()
+
+	// 问题：default case 会立即执行，不会等待通道数据
+	select {
+	case val := <-ch:
+		i18n.Printf("接收到: %d\n", val)
+	default:
+		i18n.Println("没有数据，立即返回（可能错过数据）")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	// 此时数据才到达，但已经错过了
+}
+
+// 陷阱2：在 for 循环中用带空 default 的 select 轮询通道，没有数据时循环空转，一直占用一个 CPU 核心
+//
+//readme:wrong
+func SelectDefaultTrap2() {
+	ch := make(chan int, 1)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ch <- 42
+	}()
+
+	// 问题：等待的 100ms 里循环执行了上亿次
+	for {
+		select {
+		case val := <-ch:
+			i18n.Printf("接收到: %d\n", val)
+			return
+		default:
+			// 没有数据，立即进入下一次循环
+		}
+	}
+}
+
+// printCPUTime 运行 f，并打印 f 运行期间进程占用的 CPU 时间
+func printCPUTime(f func()) {
+	start, ok := cpuTime()
+	f()
+	end, _ := cpuTime()
+	if !ok {
+		i18n.Println("当前平台无法测量 CPU 时间")
+		return
+	}
+	i18n.Printf("占用的 CPU 时间: %v\n", (end - start).Round(time.Millisecond))
+}
+
+// 正确方式1：不使用 default，等待数据
+//
+//readme:correct
+func SelectDefaultCorrectWay1() {
+	ch := make(chan int)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ch <- 42
+	}()
+
+	// 没有 default，会阻塞等待
+	select {
+	case val := <-ch:
+		i18n.Printf("接收到: %d\n", val)
+	}
+}
+
+// 正确方式2：使用 default 实现非阻塞读取
+func SelectDefaultCorrectWay() {
+	ch := make(chan int, 1) // 带缓冲
+
+	// 非阻塞发送
+	select {
+	case ch <- 42:
+		i18n.Println("发送成功")
+	default:
+		i18n.Println("通道已满，无法发送")
+	}
+
+	// 非阻塞接收
+	select {
+	case val := <-ch:
+		i18n.Printf("接收到: %d\n", val)
+	default:
+		i18n.Println("没有数据可读")
+	}
+}
+
+// 正确方式3：使用 default 实现超时
+//
+//readme:correct
+func SelectDefaultCorrectWay3() {
+	ch := make(chan int, 1) // 带一个缓冲，超时返回后发送方也不会永远阻塞
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		ch <- 42
+	}()
+
+	// 使用 default 和 time.After 实现超时
+	select {
+	case val := <-ch:
+		i18n.Printf("接收到: %d\n", val)
+	case <-time.After(100 * time.Millisecond):
+		i18n.Println("超时：没有在指定时间内收到数据")
+	}
+}
+
+// 正确方式4：去掉 default，select 阻塞到有数据为止；等待期间需要做其他事情时用 Ticker 定期唤醒
+//
+//readme:correct
+func SelectDefaultCorrectWay4() {
+	ch := make(chan int, 1)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ch <- 42
+	}()
+
+	ticker := time.NewTicker(30 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case val := <-ch:
+			i18n.Printf("接收到: %d\n", val)
+			return
+		case <-ticker.C:
+			// 定期做其他事情，其余时间 goroutine 处于阻塞状态，不占用 CPU
+		}
+	}
+}
+
+// 实际应用：超时模式
+func TimeoutPattern() {
+	ch := make(chan string, 1) // 带一个缓冲，超时返回后发送方也不会永远阻塞
+
+	go func() {
+		time.Sleep(2 * time.Second)
+		ch <- i18n.T("结果")
+	}()
+
+	select {
+	case result := <-ch:
+		i18n.Printf("成功: %s\n", result)
+	case <-time.After(1 * time.Second):
+		i18n.Println("操作超时")
+	}
+}
+
+// 实际应用：非阻塞操作
+func NonBlockingPattern() {
+	ch := make(chan int, 1)
+
+	// 尝试发送，不阻塞
+	select {
+	case ch <- 1:
+		i18n.Println("发送成功")
+	default:
+		i18n.Println("通道已满，跳过")
+	}
+
+	// 尝试接收，不阻塞
+	select {
+	case val := <-ch:
+		i18n.Printf("接收成功: %d\n", val)
+	default:
+		i18n.Println("没有数据，跳过")
+	}
+}
+
+// 实际应用：多路复用
+func MultiplexPattern() {
+	// 只会接收其中一个结果，另一个发送方要能把结果放进缓冲区后退出
+	ch1 := make(chan int, 1)
+	ch2 := make(chan string, 1)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ch1 <- 42
+	}()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ch2 <- "hello"
+	}()
+
+	// 等待任意一个通道有数据
+	select {
+	case val := <-ch1:
+		i18n.Printf("从 ch1 接收到: %d\n", val)
+	case val := <-ch2:
+		i18n.Printf("从 ch2 接收到: %s\n", val)
+	case <-time.After(200 * time.Millisecond):
+		i18n.Println("超时")
+	}
+}
+
+// 注意事项
+func selectDefaultNotes() {
+	ch := make(chan int)
+
+	// 1. 没有 default 的 select 会阻塞
+	// select {
+	// case <-ch:
+	// }
+	// 上面的代码会永远阻塞
+
+	// 2. 有 default 的 select 不会阻塞
+	select {
+	case <-ch:
+		i18n.Println("有数据")
+	default:
+		i18n.Println("没有数据，立即返回")
+	}
+
+	// 3. 多个 case 都准备好时，随机选择一个
+	// 4. 空的 select {} 会永远阻塞
+}
@@ -1,107 +1,144 @@
-package main
+package channels
 
 import (
-	"fmt"
 	"sync"
 	"time"
+
+	"go-trap/internal/i18n"
 )
 
 // 陷阱：向已关闭通道发送数据
 // 问题：向已关闭的通道发送数据会导致 panic
 
-func main() {
-	fmt.Println("=== 陷阱示例：向已关闭通道发送数据 ===")
-	
+// SendClosedDemo 依次运行“向已关闭通道发送数据”的各个示例
+func SendClosedDemo() {
+	i18n.Println("=== 陷阱示例：向已关闭通道发送数据 ===")
+
 	// 错误示例：向已关闭通道发送
-	fmt.Println("\n错误示例：")
-	// wrongWay() // 取消注释会 panic
-	
+	i18n.Println("\n错误示例：")
+	// SendClosedWrongWay() // 会 panic，用 gotrap crash channel_send_closed 在子进程中运行
+
+	// 错误示例2：由接收方关闭通道
+	i18n.Println("\n错误示例2：由接收方关闭通道")
+	// SendClosedWrongWay2() // 会 panic，用 gotrap crash channel_send_closed 在子进程中运行
+
 	// 正确示例：检查通道状态
-	fmt.Println("\n正确示例：")
-	correctWay()
-	
+	i18n.Println("\n正确示例：")
+	SendClosedCorrectWay()
+
 	time.Sleep(100 * time.Millisecond)
 }
 
 // 错误方式：向已关闭的通道发送数据
-func wrongWay() {
+//
+//readme:wrong
+func SendClosedWrongWay() {
 	ch := make(chan int)
-	
+
 	go func() {
 		close(ch)
 	}()
-	
+
 	time.Sleep(10 * time.Millisecond)
-	
+
 	// panic: send on closed channel
 	ch <- 42
 }
 
+// 错误方式2：接收方在发送方还在发送时关闭通道
+//
+//readme:wrong
+func SendClosedWrongWay2() {
+	ch := make(chan int)
+
+	// 发送方
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- i // 接收方关闭通道后，阻塞中的发送会 panic
+		}
+	}()
+
+	// 接收方只想要前两个值，收到后就关闭了通道
+	for val := range ch {
+		i18n.Printf("接收: %d\n", val)
+		if val == 1 {
+			close(ch)
+		}
+	}
+
+	// panic: send on closed channel（发生在发送方 goroutine 中）
+	time.Sleep(10 * time.Millisecond)
+}
+
 // 正确方式1：使用 sync.Once 确保只关闭一次
-func correctWay() {
+//
+//readme:correct
+func SendClosedCorrectWay() {
 	ch := make(chan int)
 	var once sync.Once
-	
+
 	// 发送方
 	go func() {
 		for i := 0; i < 3; i++ {
 			ch <- i
-			fmt.Printf("发送: %d\n", i)
+			i18n.Printf("发送: %d\n", i)
 		}
 		once.Do(func() {
 			close(ch)
-			fmt.Println("通道已关闭")
+			i18n.Println("通道已关闭")
 		})
 	}()
-	
+
 	// 接收方
 	go func() {
 		for val := range ch {
-			fmt.Printf("接收: %d\n", val)
+			i18n.Printf("接收: %d\n", val)
 		}
 	}()
-	
+
 	time.Sleep(50 * time.Millisecond)
 }
 
 // 正确方式2：使用 context 控制发送
-func correctWay2() {
+//
+//readme:correct
+func SendClosedCorrectWay2() {
 	ch := make(chan int)
 	done := make(chan struct{})
-	
+
 	// 发送方
 	go func() {
 		defer close(ch)
 		for i := 0; i < 3; i++ {
 			select {
 			case ch <- i:
-				fmt.Printf("发送: %d\n", i)
+				i18n.Printf("发送: %d\n", i)
 			case <-done:
 				return
 			}
 		}
 	}()
-	
+
 	// 接收方
 	go func() {
 		for val := range ch {
-			fmt.Printf("接收: %d\n", val)
+			i18n.Printf("接收: %d\n", val)
 		}
 		close(done)
 	}()
-	
+
 	time.Sleep(50 * time.Millisecond)
 }
 
 // 正确方式3：使用 recover 捕获 panic（不推荐，但可以用于防御性编程）
-func safeSend(ch chan int, val int) (sent bool) {
+func SafeSend(ch chan int, val int) (sent bool) {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("捕获到 panic: %v\n", r)
+			i18n.Printf("捕获到 panic: %v\n", r)
 			sent = false
 		}
 	}()
-	
+
 	ch <- val
 	return true
 }
@@ -111,10 +148,10 @@ func safeSend(ch chan int, val int) (sent bool) {
 // 2. 只在一个 goroutine 中关闭通道
 // 3. 使用 done 通道或 context 来通知停止发送
 
-func bestPractice() {
+func SendClosedBestPractice() {
 	ch := make(chan int)
 	done := make(chan struct{})
-	
+
 	// 只有一个 goroutine 负责关闭
 	go func() {
 		defer close(ch)
@@ -127,14 +164,13 @@ func bestPractice() {
 			}
 		}
 	}()
-	
+
 	// 主程序控制何时停止
 	time.Sleep(100 * time.Millisecond)
 	close(done)
-	
+
 	// 等待通道关闭
 	for range ch {
 		// 消费剩余数据
 	}
 }
-
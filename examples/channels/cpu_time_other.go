@@ -0,0 +1,10 @@
+//go:build !unix
+
+package channels
+
+import "time"
+
+// cpuTime 在不支持 getrusage 的平台上无法测量 CPU 时间
+func cpuTime() (time.Duration, bool) {
+	return 0, false
+}
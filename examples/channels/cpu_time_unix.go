@@ -0,0 +1,17 @@
+//go:build unix
+
+package channels
+
+import (
+	"syscall"
+	"time"
+)
+
+// cpuTime 返回进程到目前为止占用的 CPU 时间（用户态和内核态之和）
+func cpuTime() (time.Duration, bool) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, false
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano()), true
+}
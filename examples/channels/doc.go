@@ -0,0 +1,5 @@
+// Package channels 包含通道（Channels）相关的陷阱示例：未关闭通道、向已关闭通道发送、从已关闭通道读取和 select 的 default case。
+//
+// 每个示例文件对应 registry 中的一个陷阱，XxxDemo 函数按顺序运行该文件中的所有示例，
+// examples/cmd 下的同名命令只是调用它的薄封装。
+package channels
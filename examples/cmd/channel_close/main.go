@@ -0,0 +1,17 @@
+// channel_close 运行“未关闭通道导致泄漏”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/channels"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(channels.CloseDemo, map[string]func(){
+		"CloseWrongWay":    channels.CloseWrongWay,
+		"CloseCorrectWay":  channels.CloseCorrectWay,
+		"CloseCorrectWay2": channels.CloseCorrectWay2,
+	})
+}
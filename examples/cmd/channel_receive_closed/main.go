@@ -0,0 +1,17 @@
+// channel_receive_closed 运行“从已关闭通道读取”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/channels"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(channels.ReceiveClosedDemo, map[string]func(){
+		"ReceiveClosedTrap1":       channels.ReceiveClosedTrap1,
+		"ReceiveClosedCorrectWay":  channels.ReceiveClosedCorrectWay,
+		"ReceiveClosedCorrectWay2": channels.ReceiveClosedCorrectWay2,
+	})
+}
@@ -0,0 +1,20 @@
+// channel_select_default 运行“Select 的 Default Case”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/channels"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(channels.SelectDefaultDemo, map[string]func(){
+		"SelectDefaultTrap1":       channels.SelectDefaultTrap1,
+		"SelectDefaultTrap2":       channels.SelectDefaultTrap2,
+		"SelectDefaultCorrectWay1": channels.SelectDefaultCorrectWay1,
+		"SelectDefaultCorrectWay":  channels.SelectDefaultCorrectWay,
+		"SelectDefaultCorrectWay3": channels.SelectDefaultCorrectWay3,
+		"SelectDefaultCorrectWay4": channels.SelectDefaultCorrectWay4,
+	})
+}
@@ -0,0 +1,18 @@
+// channel_send_closed 运行“向已关闭通道发送数据”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/channels"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(channels.SendClosedDemo, map[string]func(){
+		"SendClosedWrongWay":    channels.SendClosedWrongWay,
+		"SendClosedWrongWay2":   channels.SendClosedWrongWay2,
+		"SendClosedCorrectWay":  channels.SendClosedCorrectWay,
+		"SendClosedCorrectWay2": channels.SendClosedCorrectWay2,
+	})
+}
@@ -0,0 +1,19 @@
+// defer_order 运行“Defer 的执行顺序”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.DeferDemo, map[string]func(){
+		"DeferTrap1":       misc.DeferTrap1,
+		"DeferTrap2":       misc.DeferTrap2,
+		"DeferTrap3":       misc.DeferTrap3,
+		"DeferCorrectWay":  misc.DeferCorrectWay,
+		"DeferCorrectWay2": misc.DeferCorrectWay2,
+	})
+}
@@ -0,0 +1,20 @@
+// error_handling 运行“错误处理”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.ErrorHandlingDemo, map[string]func(){
+		"ErrorHandlingTrap1":       misc.ErrorHandlingTrap1,
+		"ErrorHandlingTrap2":       misc.ErrorHandlingTrap2,
+		"ErrorHandlingTrap3":       misc.ErrorHandlingTrap3,
+		"ErrorHandlingCorrectWay":  misc.ErrorHandlingCorrectWay,
+		"ErrorHandlingCorrectWay2": misc.ErrorHandlingCorrectWay2,
+		"ErrorHandlingCorrectWay3": misc.ErrorHandlingCorrectWay3,
+	})
+}
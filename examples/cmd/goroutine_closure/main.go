@@ -0,0 +1,18 @@
+// goroutine_closure 运行“闭包变量捕获问题”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/goroutines"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(goroutines.ClosureDemo, map[string]func(){
+		"ClosureWrongWay":    goroutines.ClosureWrongWay,
+		"ClosureWrongWay2":   goroutines.ClosureWrongWay2,
+		"ClosureCorrectWay":  goroutines.ClosureCorrectWay,
+		"ClosureCorrectWay2": goroutines.ClosureCorrectWay2,
+	})
+}
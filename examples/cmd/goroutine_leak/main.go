@@ -0,0 +1,20 @@
+// goroutine_leak 运行“Goroutine 泄漏”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/goroutines"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(goroutines.LeakDemo, map[string]func(){
+		"LeakWrongWay":    goroutines.LeakWrongWay,
+		"LeakWrongWay2":   goroutines.LeakWrongWay2,
+		"LeakCorrectWay":  goroutines.LeakCorrectWay,
+		"LeakCorrectWay2": goroutines.LeakCorrectWay2,
+		"LeakCorrectWay3": goroutines.LeakCorrectWay3,
+		"LeakCorrectWay4": goroutines.LeakCorrectWay4,
+	})
+}
@@ -0,0 +1,16 @@
+// goroutine_wait 运行“未等待 Goroutine 完成”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/goroutines"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(goroutines.WaitDemo, map[string]func(){
+		"WaitWrongWay":   goroutines.WaitWrongWay,
+		"WaitCorrectWay": goroutines.WaitCorrectWay,
+	})
+}
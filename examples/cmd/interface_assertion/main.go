@@ -0,0 +1,16 @@
+// interface_assertion 运行“接口类型断言”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/interfaces"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(interfaces.AssertionDemo, map[string]func(){
+		"AssertionWrongWay":   interfaces.AssertionWrongWay,
+		"AssertionCorrectWay": interfaces.AssertionCorrectWay,
+	})
+}
@@ -0,0 +1,16 @@
+// interface_empty 运行“空接口的使用”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/interfaces"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(interfaces.EmptyDemo, map[string]func(){
+		"EmptyTrap1":      interfaces.EmptyTrap1,
+		"EmptyCorrectWay": interfaces.EmptyCorrectWay,
+	})
+}
@@ -0,0 +1,18 @@
+// interface_nil 运行“Nil 接口值”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/interfaces"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(interfaces.NilDemo, map[string]func(){
+		"NilTrap1":         interfaces.NilTrap1,
+		"NilTrap2":         interfaces.NilTrap2,
+		"NilCorrectWay":    interfaces.NilCorrectWay,
+		"DemonstrateError": interfaces.DemonstrateError,
+	})
+}
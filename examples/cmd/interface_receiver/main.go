@@ -0,0 +1,17 @@
+// interface_receiver 运行“Interface 接收者问题”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/interfaces"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(interfaces.ReceiverDemo, map[string]func(){
+		"ReceiverTrap1":      interfaces.ReceiverTrap1,
+		"ReceiverTrap2":      interfaces.ReceiverTrap2,
+		"ReceiverCorrectWay": interfaces.ReceiverCorrectWay,
+	})
+}
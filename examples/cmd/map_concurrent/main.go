@@ -0,0 +1,19 @@
+// map_concurrent 运行“Map 的并发读写”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.MapConcurrentDemo, map[string]func(){
+		"MapConcurrentWrongWay":    misc.MapConcurrentWrongWay,
+		"MapConcurrentCorrectWay1": misc.MapConcurrentCorrectWay1,
+		"MapConcurrentCorrectWay2": misc.MapConcurrentCorrectWay2,
+		"MapConcurrentCorrectWay3": misc.MapConcurrentCorrectWay3,
+		"DemonstrateCounter":       misc.DemonstrateCounter,
+	})
+}
@@ -0,0 +1,19 @@
+// map_key_type 运行“Map 键类型限制”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.MapKeyDemo, map[string]func(){
+		"MapKeyTrap1":       misc.MapKeyTrap1,
+		"MapKeyTrap2":       misc.MapKeyTrap2,
+		"MapKeyCorrectWay":  misc.MapKeyCorrectWay,
+		"MapKeyCorrectWay2": misc.MapKeyCorrectWay2,
+		"MapKeyCorrectWay3": misc.MapKeyCorrectWay3,
+	})
+}
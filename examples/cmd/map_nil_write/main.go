@@ -0,0 +1,20 @@
+// map_nil_write 运行“nil map 写入”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.NilMapDemo, map[string]func(){
+		"NilMapTrap1":       misc.NilMapTrap1,
+		"NilMapTrap2":       misc.NilMapTrap2,
+		"NilMapTrap3":       misc.NilMapTrap3,
+		"NilMapTrap4":       misc.NilMapTrap4,
+		"NilMapCorrectWay":  misc.NilMapCorrectWay,
+		"NilMapCorrectWay2": misc.NilMapCorrectWay2,
+	})
+}
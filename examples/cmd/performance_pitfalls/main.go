@@ -0,0 +1,25 @@
+// performance_pitfalls 运行“性能问题”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.PerfDemo, map[string]func(){
+		"PerfTrap1":       misc.PerfTrap1,
+		"PerfTrap2":       misc.PerfTrap2,
+		"PerfTrap3":       misc.PerfTrap3,
+		"PerfTrap4":       misc.PerfTrap4,
+		"PerfTrap5":       misc.PerfTrap5,
+		"PerfTrap6":       misc.PerfTrap6,
+		"PerfCorrectWay":  misc.PerfCorrectWay,
+		"PerfCorrectWay2": misc.PerfCorrectWay2,
+		"PerfCorrectWay3": misc.PerfCorrectWay3,
+		"PerfCorrectWay4": misc.PerfCorrectWay4,
+		"PerfCorrectWay6": misc.PerfCorrectWay6,
+	})
+}
@@ -0,0 +1,16 @@
+// pointer_nil 运行“Nil 指针解引用”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/pointers"
+)
+
+func main() {
+	demo.Main(pointers.NilDemo, map[string]func(){
+		"NilWrongWay":   pointers.NilWrongWay,
+		"NilCorrectWay": pointers.NilCorrectWay,
+	})
+}
@@ -0,0 +1,19 @@
+// slice_array 运行“切片和数组的区别”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.SliceArrayDemo, map[string]func(){
+		"SliceArrayTrap1":       misc.SliceArrayTrap1,
+		"SliceArrayTrap2":       misc.SliceArrayTrap2,
+		"SliceArrayTrap3":       misc.SliceArrayTrap3,
+		"SliceArrayCorrectWay":  misc.SliceArrayCorrectWay,
+		"SliceArrayCorrectWay2": misc.SliceArrayCorrectWay2,
+	})
+}
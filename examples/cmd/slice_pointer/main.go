@@ -0,0 +1,19 @@
+// slice_pointer 运行“切片中的指针问题”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/pointers"
+)
+
+func main() {
+	demo.Main(pointers.SlicePointerDemo, map[string]func(){
+		"SlicePointerTrap1":       pointers.SlicePointerTrap1,
+		"SlicePointerTrap2":       pointers.SlicePointerTrap2,
+		"SlicePointerCorrectWay":  pointers.SlicePointerCorrectWay,
+		"SlicePointerCorrectWay2": pointers.SlicePointerCorrectWay2,
+		"SlicePointerCorrectWay3": pointers.SlicePointerCorrectWay3,
+	})
+}
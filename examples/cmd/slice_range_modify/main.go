@@ -0,0 +1,22 @@
+// slice_range_modify 运行“切片遍历时修改”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.RangeModifyDemo, map[string]func(){
+		"RangeModifyTrap1":       misc.RangeModifyTrap1,
+		"RangeModifyTrap2":       misc.RangeModifyTrap2,
+		"RangeModifyTrap3":       misc.RangeModifyTrap3,
+		"RangeModifyTrap4":       misc.RangeModifyTrap4,
+		"RangeModifyCorrectWay":  misc.RangeModifyCorrectWay,
+		"RangeModifyCorrectWay2": misc.RangeModifyCorrectWay2,
+		"RangeModifyCorrectWay3": misc.RangeModifyCorrectWay3,
+		"RangeModifyCorrectWay4": misc.RangeModifyCorrectWay4,
+	})
+}
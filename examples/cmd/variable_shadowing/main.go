@@ -0,0 +1,20 @@
+// variable_shadowing 运行“变量遮蔽（Variable Shadowing）”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/internal/demo"
+	"go-trap/examples/misc"
+)
+
+func main() {
+	demo.Main(misc.ShadowingDemo, map[string]func(){
+		"ShadowingTrap1":       misc.ShadowingTrap1,
+		"ShadowingTrap2":       misc.ShadowingTrap2,
+		"ShadowingTrap3":       misc.ShadowingTrap3,
+		"ShadowingCorrectWay":  misc.ShadowingCorrectWay,
+		"ShadowingCorrectWay2": misc.ShadowingCorrectWay2,
+		"ShadowingCorrectWay3": misc.ShadowingCorrectWay3,
+	})
+}
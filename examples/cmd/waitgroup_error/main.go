@@ -0,0 +1,19 @@
+// waitgroup_error 运行“WaitGroup 使用错误”示例。
+//
+// 使用 -run 函数名 可以只运行其中一个示例函数，-list 列出可以单独运行的函数。
+package main
+
+import (
+	"go-trap/examples/goroutines"
+	"go-trap/examples/internal/demo"
+)
+
+func main() {
+	demo.Main(goroutines.WaitGroupDemo, map[string]func(){
+		"WaitGroupTrap1":       goroutines.WaitGroupTrap1,
+		"WaitGroupTrap2":       goroutines.WaitGroupTrap2,
+		"WaitGroupTrap3":       goroutines.WaitGroupTrap3,
+		"WaitGroupCorrectWay":  goroutines.WaitGroupCorrectWay,
+		"WaitGroupCorrectWay2": goroutines.WaitGroupCorrectWay2,
+	})
+}
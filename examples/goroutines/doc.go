@@ -0,0 +1,5 @@
+// Package goroutines 包含协程（Goroutines）相关的陷阱示例：闭包变量捕获、未等待 goroutine、goroutine 泄漏和 WaitGroup 使用错误。
+//
+// 每个示例文件对应 registry 中的一个陷阱，XxxDemo 函数按顺序运行该文件中的所有示例，
+// examples/cmd 下的同名命令只是调用它的薄封装。
+package goroutines
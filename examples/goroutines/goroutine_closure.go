@@ -0,0 +1,83 @@
+package goroutines
+
+import (
+	"sync"
+	"time"
+
+	"go-trap/internal/i18n"
+)
+
+// 陷阱：闭包变量捕获问题
+// 问题：在循环中使用 goroutine 时，所有 goroutine 可能共享同一个变量
+
+// ClosureDemo 依次运行“闭包变量捕获问题”的各个示例
+func ClosureDemo() {
+	i18n.Println("=== 陷阱示例：闭包变量捕获 ===")
+
+	// 错误示例：所有 goroutine 共享变量 i
+	i18n.Println("\n错误示例：")
+	ClosureWrongWay()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// 正确示例：通过参数传递或创建局部变量
+	i18n.Println("\n正确示例：")
+	ClosureCorrectWay()
+
+	time.Sleep(100 * time.Millisecond)
+}
+
+// 错误方式：所有 goroutine 都读取到循环结束后的 i 值
+//
+//readme:wrong
+func ClosureWrongWay() {
+	for i := 0; i < 5; i++ {
+		go func() {
+			i18n.Printf("错误: i = %d\n", i) // 所有 goroutine 可能都打印 5
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+// 错误方式2：多个 goroutine 通过闭包修改同一个外部变量
+// Go 1.22 只让循环变量每次迭代独立，循环外声明的变量仍然被所有 goroutine 共享，存在数据竞争
+//
+//readme:wrong
+func ClosureWrongWay2() {
+	var wg sync.WaitGroup
+	sum := 0
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sum += i // 多个 goroutine 同时读写 sum
+		}()
+	}
+	wg.Wait()
+	i18n.Printf("错误: sum = %d（可能小于 10）\n", sum)
+}
+
+// 正确方式1：通过参数传递
+//
+//readme:correct
+func ClosureCorrectWay() {
+	for i := 0; i < 5; i++ {
+		go func(val int) {
+			i18n.Printf("正确: val = %d\n", val)
+		}(i) // 将 i 作为参数传递
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+// 正确方式2：在循环内创建局部变量
+//
+//readme:correct
+func ClosureCorrectWay2() {
+	for i := 0; i < 5; i++ {
+		i := i // 创建局部变量
+		go func() {
+			i18n.Printf("正确: i = %d\n", i)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+}
@@ -0,0 +1,164 @@
+package goroutines
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"go-trap/internal/i18n"
+)
+
+// 陷阱：Goroutine 泄漏
+// 问题：goroutine 因为通道阻塞而永远无法退出，造成内存泄漏
+
+// LeakDemo 依次运行“Goroutine 泄漏”的各个示例
+func LeakDemo() {
+	i18n.Println("=== 陷阱示例：Goroutine 泄漏 ===")
+
+	// 错误示例：goroutine 永远阻塞
+	i18n.Println("\n错误示例：")
+	LeakWrongWay()
+
+	time.Sleep(200 * time.Millisecond)
+
+	// 错误示例2：超时返回后，发送结果的 goroutine 永远阻塞
+	i18n.Println("\n错误示例2：超时返回后发送方永远阻塞")
+	countLeaks(LeakWrongWay2)
+
+	// 正确示例：使用 context 或关闭通道
+	i18n.Println("\n正确示例：")
+	LeakCorrectWay()
+
+	time.Sleep(200 * time.Millisecond)
+
+	// 正确示例：超时时发送方也能退出
+	i18n.Println("\n正确示例：超时时发送方也能退出")
+	countLeaks(LeakCorrectWay3)
+	countLeaks(LeakCorrectWay4)
+}
+
+// countLeaks 运行 f，等 f 启动的 goroutine 完成工作后打印多出来的 goroutine 数量
+func countLeaks(f func()) {
+	before := runtime.NumGoroutine()
+	f()
+	time.Sleep(200 * time.Millisecond)
+	i18n.Printf("泄漏的 goroutine 数量: %d\n", runtime.NumGoroutine()-before)
+}
+
+// 错误方式：goroutine 永远阻塞在通道上
+//
+//readme:wrong
+func LeakWrongWay() {
+	ch := make(chan int)
+
+	// 这个 goroutine 会永远阻塞，因为没有人会向通道发送数据
+	go func() {
+		val := <-ch // 永远阻塞在这里
+		i18n.Printf("收到值: %d\n", val)
+	}()
+
+	i18n.Println("Goroutine 已启动（但会永远阻塞）")
+	// 主程序退出，但 goroutine 仍在运行，造成泄漏
+}
+
+// 错误方式2：调用方超时返回后，没有人再接收结果，发送结果的 goroutine 永远阻塞
+//
+//readme:wrong
+func LeakWrongWay2() {
+	ch := make(chan int) // 无缓冲通道
+
+	go func() {
+		time.Sleep(100 * time.Millisecond) // 模拟耗时的操作
+		ch <- 42                           // 调用方已经超时返回，这里会永远阻塞
+	}()
+
+	select {
+	case val := <-ch:
+		i18n.Printf("收到值: %d\n", val)
+	case <-time.After(50 * time.Millisecond):
+		i18n.Println("超时")
+	}
+}
+
+// 正确方式1：使用带缓冲的通道或确保有发送者
+//
+//readme:correct
+func LeakCorrectWay() {
+	ch := make(chan int, 1) // 带缓冲的通道
+
+	go func() {
+		val := <-ch
+		i18n.Printf("收到值: %d\n", val)
+	}()
+
+	ch <- 42 // 发送数据
+	time.Sleep(50 * time.Millisecond)
+	i18n.Println("Goroutine 正常完成")
+}
+
+// 正确方式2：使用 context 控制 goroutine 生命周期
+//
+//readme:correct
+func LeakCorrectWay2() {
+	ch := make(chan int)
+	done := make(chan bool)
+
+	go func() {
+		select {
+		case val := <-ch:
+			i18n.Printf("收到值: %d\n", val)
+		case <-done:
+			i18n.Println("收到退出信号")
+			return
+		}
+	}()
+
+	// 如果不需要继续运行，发送退出信号
+	close(done)
+	time.Sleep(50 * time.Millisecond)
+	i18n.Println("Goroutine 正常退出")
+}
+
+// 正确方式3：结果通道带一个缓冲，调用方超时返回后发送也不会阻塞
+//
+//readme:correct
+func LeakCorrectWay3() {
+	ch := make(chan int, 1) // 缓冲区大小为 1，发送方总能放下结果
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ch <- 42 // 没有人接收时结果留在缓冲区中，随通道一起被回收
+	}()
+
+	select {
+	case val := <-ch:
+		i18n.Printf("收到值: %d\n", val)
+	case <-time.After(50 * time.Millisecond):
+		i18n.Println("超时")
+	}
+}
+
+// 正确方式4：发送时同时等待 context，调用方放弃后发送方直接退出
+//
+//readme:correct
+func LeakCorrectWay4() {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	ch := make(chan int)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		select {
+		case ch <- 42:
+		case <-ctx.Done(): // 调用方已经放弃，不再等待接收方
+		}
+	}()
+
+	select {
+	case val := <-ch:
+		i18n.Printf("收到值: %d\n", val)
+	case <-ctx.Done():
+		i18n.Println("超时")
+	}
+}
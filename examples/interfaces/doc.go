@@ -0,0 +1,5 @@
+// Package interfaces 包含接口（Interfaces）相关的陷阱示例：nil 接口值、类型断言、空接口和接口接收者。
+//
+// 每个示例文件对应 registry 中的一个陷阱，XxxDemo 函数按顺序运行该文件中的所有示例，
+// examples/cmd 下的同名命令只是调用它的薄封装。
+package interfaces
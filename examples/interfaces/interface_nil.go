@@ -0,0 +1,144 @@
+package interfaces
+
+import "go-trap/internal/i18n"
+
+// 陷阱：Nil 接口值
+// 问题：接口值为 nil 但接口类型不为 nil，导致判断错误
+
+type Writer interface {
+	Write([]byte) (int, error)
+}
+
+type MyWriter struct {
+	data []byte
+}
+
+func (w *MyWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+// NilDemo 依次运行“Nil 接口值”的各个示例
+func NilDemo() {
+	i18n.Println("=== 陷阱示例：Nil 接口值 ===")
+
+	// 陷阱1：接口值为 nil，但接口本身不为 nil
+	i18n.Println("\n陷阱1：接口值为 nil，但接口本身不为 nil")
+	NilTrap1()
+
+	// 陷阱2：nil 指针实现接口
+	i18n.Println("\n陷阱2：nil 指针实现接口")
+	NilTrap2()
+
+	// 陷阱3：返回 nil 指针的 error 不为 nil
+	i18n.Println("\n陷阱3：返回 nil 指针的 error 不为 nil")
+	DemonstrateError()
+	i18n.Printf("ValidateName(\"gopher\") == nil: %v\n", ValidateName("gopher") == nil)                     // false!
+	i18n.Printf("ValidateNameCorrectWay(\"gopher\") == nil: %v\n", ValidateNameCorrectWay("gopher") == nil) // true
+
+	// 正确方式：检查接口值和类型
+	i18n.Println("\n正确方式：检查接口值和类型")
+	NilCorrectWay()
+}
+
+//readme:wrong
+func NilTrap1() {
+	var w Writer
+	var mw *MyWriter = nil
+
+	// mw 是 nil 指针
+	i18n.Printf("mw == nil: %v\n", mw == nil) // true
+
+	// 但是将 nil 指针赋值给接口后，接口不为 nil
+	w = mw
+	i18n.Printf("w == nil: %v\n", w == nil) // false!
+
+	// 因为接口包含类型信息 (*MyWriter) 和值 (nil)
+	// 所以接口本身不为 nil
+}
+
+func NilTrap2() {
+	var w Writer = (*MyWriter)(nil)
+
+	// 接口不为 nil
+	if w != nil {
+		i18n.Println("接口不为 nil，可以调用方法")
+		// 但是调用方法会 panic，因为底层值是 nil
+		// w.Write([]byte("test")) // panic: runtime error: invalid memory address
+	}
+}
+
+//readme:correct
+func NilCorrectWay() {
+	var w Writer
+	var mw *MyWriter = nil
+
+	// 方式1：在赋值前检查
+	if mw != nil {
+		w = mw
+	}
+
+	// 方式2：使用类型断言检查
+	w = mw
+	if w != nil {
+		if mw, ok := w.(*MyWriter); ok && mw != nil {
+			mw.Write([]byte("safe"))
+			i18n.Println("安全调用")
+		} else {
+			i18n.Println("接口值或类型为 nil，不能调用")
+		}
+	}
+
+	// 方式3：使用反射检查（更复杂但更准确）
+	// import "reflect"
+	// if w != nil && reflect.ValueOf(w).IsNil() {
+	//     // 处理 nil 情况
+	// }
+}
+
+// 实际应用：错误处理
+type MyError struct {
+	msg string
+}
+
+func (e *MyError) Error() string {
+	if e == nil {
+		return "nil error"
+	}
+	return e.msg
+}
+
+//readme:wrong
+func ReturnError() error {
+	var err *MyError = nil
+	return err // 返回的 error 接口不为 nil！
+}
+
+// 只在出错的分支给错误指针赋值，没有出错时返回的仍然是 (*MyError)(nil)
+//
+//readme:wrong
+func ValidateName(name string) error {
+	var err *MyError
+	if name == "" {
+		err = &MyError{msg: "name is empty"}
+	}
+	return err // name 不为空时返回的 error 也不为 nil！
+}
+
+// 正确方式：没有错误时直接返回 nil，不要经过具体类型的指针变量
+//
+//readme:correct
+func ValidateNameCorrectWay(name string) error {
+	if name == "" {
+		return &MyError{msg: "name is empty"}
+	}
+	return nil
+}
+
+func DemonstrateError() {
+	err := ReturnError()
+	if err != nil {
+		i18n.Println("错误不为 nil")  // 会执行这里
+		i18n.Println(err.Error()) // 输出 "nil error"
+	}
+}
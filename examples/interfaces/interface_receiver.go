@@ -1,26 +1,28 @@
-package main
+package interfaces
 
-import "fmt"
+import "go-trap/internal/i18n"
 
 // 陷阱：Interface 接收者问题
 // 问题：接口方法接收者的选择影响接口实现
 
-type Writer interface {
-	Write([]byte) (int, error)
-}
+// Writer 接口定义在 interface_nil.go 中
 
-type MyWriter struct {
+type ReceiverWriter struct {
 	data []byte
 }
 
 // 值接收者实现接口
-func (w MyWriter) Write(p []byte) (int, error) {
+//
+//readme:wrong
+func (w ReceiverWriter) Write(p []byte) (int, error) {
 	w.data = append(w.data, p...)
 	return len(p), nil
 }
 
 // 指针接收者实现接口
-func (w *MyWriter) WritePointer(p []byte) (int, error) {
+//
+//readme:correct
+func (w *ReceiverWriter) WritePointer(p []byte) (int, error) {
 	w.data = append(w.data, p...)
 	return len(p), nil
 }
@@ -29,52 +31,57 @@ type PointerWriter interface {
 	WritePointer([]byte) (int, error)
 }
 
-func main() {
-	fmt.Println("=== 陷阱示例：Interface 接收者问题 ===")
+// ReceiverDemo 依次运行“Interface 接收者问题”的各个示例
+func ReceiverDemo() {
+	i18n.Println("=== 陷阱示例：Interface 接收者问题 ===")
 
 	// 陷阱1：值接收者 vs 指针接收者
-	fmt.Println("\n陷阱1：值接收者 vs 指针接收者")
-	trap1()
+	i18n.Println("\n陷阱1：值接收者 vs 指针接收者")
+	ReceiverTrap1()
 
 	// 陷阱2：接口赋值问题
-	fmt.Println("\n陷阱2：接口赋值问题")
-	trap2()
+	i18n.Println("\n陷阱2：接口赋值问题")
+	ReceiverTrap2()
 
 	// 正确方式
-	fmt.Println("\n正确方式：")
-	correctWay()
+	i18n.Println("\n正确方式：")
+	ReceiverCorrectWay()
 }
 
 // 陷阱1：值接收者实现接口
-func trap1() {
+//
+//readme:wrong
+func ReceiverTrap1() {
 	var w Writer
 
 	// 值类型可以实现接口
-	mw1 := MyWriter{}
+	mw1 := ReceiverWriter{}
 	w = mw1
 	w.Write([]byte("test"))
-	fmt.Printf("值接收者: %v\n", mw1.data) // 空，因为修改的是副本
+	i18n.Printf("值接收者: %v\n", mw1.data) // 空，因为修改的是副本
 
 	// 指针类型也可以实现接口（Go 自动转换）
-	mw2 := &MyWriter{}
+	mw2 := &ReceiverWriter{}
 	w = mw2
 	w.Write([]byte("test"))
-	fmt.Printf("指针类型调用值接收者: %v\n", mw2.data) // 仍然是空
+	i18n.Printf("指针类型调用值接收者: %v\n", mw2.data) // 仍然是空
 }
 
 // 陷阱2：指针接收者实现接口
-func trap2() {
+//
+//readme:correct
+func ReceiverTrap2() {
 	var pw PointerWriter
 
 	// 值类型不能赋值给需要指针接收者的接口
-	// mw1 := MyWriter{}
+	// mw1 := ReceiverWriter{}
 	// pw = mw1 // 编译错误！
 
 	// 必须使用指针
-	mw2 := &MyWriter{}
+	mw2 := &ReceiverWriter{}
 	pw = mw2
 	pw.WritePointer([]byte("test"))
-	fmt.Printf("指针接收者: %v\n", mw2.data) // 有数据
+	i18n.Printf("指针接收者: %v\n", mw2.data) // 有数据
 }
 
 // Counter 类型定义
@@ -93,15 +100,15 @@ func (c Counter) GetCount() int {
 }
 
 // 正确方式：根据需求选择接收者类型
-func correctWay() {
+func ReceiverCorrectWay() {
 	// 如果方法需要修改接收者，使用指针接收者
 	c := Counter{}
 	c.Increment()
-	fmt.Printf("计数: %d\n", c.GetCount())
+	i18n.Printf("计数: %d\n", c.GetCount())
 }
 
 // 实际应用：接口设计原则
-func designPrinciples() {
+func receiverDesignPrinciples() {
 	// 1. 如果方法需要修改接收者，使用指针接收者
 	// 2. 如果接收者是大结构体，使用指针接收者（避免复制）
 	// 3. 如果接收者是值类型（如 int, string），使用值接收者
@@ -130,7 +137,7 @@ func (f *File) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
-func demonstrateMethodSet() {
+func DemonstrateMethodSet() {
 	// 值类型的方法集只包含值接收者的方法
 	// var f1 File
 	// var rw1 ReadWriter = f1 // 编译错误！f1 没有实现 Write
@@ -0,0 +1,52 @@
+// Package demo 为 examples/cmd 下的示例命令提供统一的入口。
+package demo
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"go-trap/internal/i18n"
+)
+
+// Main 解析命令行参数并运行示例。
+// 没有参数时运行 all，即示例文件的完整演示；
+// 使用 -run 名称 时只运行 funcs 中对应的函数，gotrap 用它在子进程中单独运行会崩溃的错误示例。
+// -lang 选择输出语言，没有指定时按 LANG 等环境变量选择。
+func Main(all func(), funcs map[string]func()) {
+	run := flag.String("run", "", i18n.T("只运行指定的示例函数"))
+	list := flag.Bool("list", false, i18n.T("列出可以单独运行的示例函数"))
+	lang := flag.String("lang", "", i18n.T("输出语言：zh 或 en，默认按 LANG 环境变量选择"))
+	flag.Parse()
+
+	if *lang != "" {
+		l, err := i18n.Parse(*lang)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		i18n.SetLang(l)
+	}
+
+	switch {
+	case *list:
+		names := make([]string, 0, len(funcs))
+		for name := range funcs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	case *run != "":
+		fn, ok := funcs[*run]
+		if !ok {
+			fmt.Fprint(os.Stderr, i18n.Sprintf("未知的示例函数: %s\n", *run))
+			os.Exit(2)
+		}
+		fn()
+	default:
+		all()
+	}
+}
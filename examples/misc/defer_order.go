@@ -1,41 +1,48 @@
-package main
+package misc
 
-import "fmt"
+import (
+	"fmt"
+
+	"go-trap/internal/i18n"
+)
 
 // 陷阱：Defer 的执行顺序
 // 问题：defer 语句的执行顺序和参数求值时机容易混淆
 
-func main() {
-	fmt.Println("=== 陷阱示例：Defer 的执行顺序 ===")
-	
+// DeferDemo 依次运行“Defer 的执行顺序”的各个示例
+func DeferDemo() {
+	i18n.Println("=== 陷阱示例：Defer 的执行顺序 ===")
+
 	// 陷阱1：defer 的参数立即求值
-	fmt.Println("\n陷阱1：defer 的参数立即求值")
-	trap1()
-	
+	i18n.Println("\n陷阱1：defer 的参数立即求值")
+	DeferTrap1()
+
 	// 陷阱2：defer 的执行顺序（LIFO）
-	fmt.Println("\n陷阱2：defer 的执行顺序（LIFO）")
-	trap2()
-	
+	i18n.Println("\n陷阱2：defer 的执行顺序（LIFO）")
+	DeferTrap2()
+
 	// 陷阱3：defer 修改返回值
-	fmt.Println("\n陷阱3：defer 修改返回值")
-	trap3()
-	
+	i18n.Println("\n陷阱3：defer 修改返回值")
+	DeferTrap3()
+
 	// 正确方式
-	fmt.Println("\n正确方式：")
-	correctWay()
+	i18n.Println("\n正确方式：")
+	DeferCorrectWay()
 }
 
 // 陷阱1：defer 的参数在调用时立即求值
-func trap1() {
+//
+//readme:wrong
+func DeferTrap1() {
 	i := 0
-	defer fmt.Println("defer 1:", i) // i 的值是 0（立即求值）
-	
+	defer i18n.Println("defer 1:", i) // i 的值是 0（立即求值）
+
 	i++
-	defer fmt.Println("defer 2:", i) // i 的值是 1（立即求值）
-	
+	defer i18n.Println("defer 2:", i) // i 的值是 1（立即求值）
+
 	i++
-	fmt.Println("函数结束:", i) // i 的值是 2
-	
+	i18n.Println("函数结束:", i) // i 的值是 2
+
 	// 输出顺序：
 	// 函数结束: 2
 	// defer 2: 1
@@ -43,13 +50,13 @@ func trap1() {
 }
 
 // 陷阱2：defer 的执行顺序是 LIFO（后进先出）
-func trap2() {
-	defer fmt.Println("第一个 defer")
-	defer fmt.Println("第二个 defer")
-	defer fmt.Println("第三个 defer")
-	
-	fmt.Println("函数执行")
-	
+func DeferTrap2() {
+	defer i18n.Println("第一个 defer")
+	defer i18n.Println("第二个 defer")
+	defer i18n.Println("第三个 defer")
+
+	i18n.Println("函数执行")
+
 	// 输出顺序：
 	// 函数执行
 	// 第三个 defer
@@ -58,13 +65,13 @@ func trap2() {
 }
 
 // 陷阱3：defer 可以修改命名返回值
-func trap3() {
-	fmt.Println("返回值:", returnValue1()) // 返回 2
-	fmt.Println("返回值:", returnValue2()) // 返回 1
+func DeferTrap3() {
+	i18n.Println("返回值:", ReturnValue1()) // 返回 2
+	i18n.Println("返回值:", ReturnValue2()) // 返回 1
 }
 
 // 命名返回值，defer 可以修改
-func returnValue1() (result int) {
+func ReturnValue1() (result int) {
 	defer func() {
 		result++ // 修改返回值
 	}()
@@ -72,7 +79,7 @@ func returnValue1() (result int) {
 }
 
 // 匿名返回值，defer 不能修改
-func returnValue2() int {
+func ReturnValue2() int {
 	result := 1
 	defer func() {
 		result++ // 修改局部变量，不影响返回值
@@ -81,34 +88,36 @@ func returnValue2() int {
 }
 
 // 正确方式1：使用闭包访问最新值
-func correctWay() {
+//
+//readme:correct
+func DeferCorrectWay() {
 	i := 0
 	defer func() {
-		fmt.Println("defer:", i) // 使用闭包，访问最新的 i
+		i18n.Println("defer:", i) // 使用闭包，访问最新的 i
 	}()
-	
+
 	i++
-	fmt.Println("函数结束:", i)
-	
+	i18n.Println("函数结束:", i)
+
 	// 输出：
 	// 函数结束: 1
 	// defer: 1
 }
 
 // 正确方式2：理解 defer 的执行时机
-func correctWay2() {
-	fmt.Println("开始")
-	
+func DeferCorrectWay2() {
+	i18n.Println("开始")
+
 	defer func() {
-		fmt.Println("defer 1")
+		i18n.Println("defer 1")
 	}()
-	
+
 	defer func() {
-		fmt.Println("defer 2")
+		i18n.Println("defer 2")
 	}()
-	
-	fmt.Println("结束")
-	
+
+	i18n.Println("结束")
+
 	// 输出：
 	// 开始
 	// 结束
@@ -117,39 +126,36 @@ func correctWay2() {
 }
 
 // 实际应用：资源清理
-func resourceCleanup() {
-	fmt.Println("打开资源")
-	
+func DeferResourceCleanup() {
+	i18n.Println("打开资源")
+
 	defer func() {
-		fmt.Println("清理资源")
+		i18n.Println("清理资源")
 	}()
-	
-	fmt.Println("使用资源")
-	
+
+	i18n.Println("使用资源")
+
 	// 即使发生 panic，defer 也会执行
 	// panic("错误")
 }
 
 // 实际应用：错误处理
-func errorHandling() (err error) {
+func DeferErrorHandling() (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("panic: %v", r)
 		}
 	}()
-	
-	// 可能 panic 的代码
-	panic("测试错误")
-	
-	return nil
+
+	// 可能 panic 的代码，panic 之后的 return 不会执行
+	panic(i18n.T("测试错误"))
 }
 
 // 注意事项
-func notes() {
+func deferNotes() {
 	// 1. defer 的参数在调用时立即求值
 	// 2. defer 在函数返回前执行（LIFO 顺序）
 	// 3. defer 可以修改命名返回值
 	// 4. defer 中的闭包会捕获最新的变量值
 	// 5. defer 即使发生 panic 也会执行
 }
-
@@ -0,0 +1,5 @@
+// Package misc 包含其他常见陷阱示例：切片、map、defer、错误处理、变量遮蔽和性能问题。
+//
+// 每个示例文件对应 registry 中的一个陷阱，XxxDemo 函数按顺序运行该文件中的所有示例，
+// examples/cmd 下的同名命令只是调用它的薄封装。
+package misc
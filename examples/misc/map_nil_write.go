@@ -0,0 +1,143 @@
+package misc
+
+import "go-trap/internal/i18n"
+
+// 陷阱：nil map 写入
+// 问题：向 nil map 写入数据会导致 panic
+
+// NilMapDemo 依次运行“nil map 写入”的各个示例
+func NilMapDemo() {
+	i18n.Println("=== 陷阱示例：nil map 写入 ===")
+
+	// 陷阱1：向 nil map 写入
+	i18n.Println("\n陷阱1：向 nil map 写入")
+	// NilMapTrap1() // 会 panic，用 gotrap crash map_nil_write 在子进程中运行
+
+	// 陷阱2：nil map 读取
+	i18n.Println("\n陷阱2：nil map 读取")
+	NilMapTrap2()
+
+	// 陷阱3：结构体中的 map 字段没有初始化
+	i18n.Println("\n陷阱3：结构体中的 map 字段没有初始化")
+	// NilMapTrap3() // 会 panic，用 gotrap crash map_nil_write 在子进程中运行
+
+	// 陷阱4：调用方传入 nil map
+	i18n.Println("\n陷阱4：调用方传入 nil map")
+	// NilMapTrap4() // 会 panic，用 gotrap crash map_nil_write 在子进程中运行
+
+	// 正确方式
+	i18n.Println("\n正确方式：")
+	NilMapCorrectWay()
+}
+
+// 陷阱1：向 nil map 写入
+//
+//readme:wrong
+func NilMapTrap1() {
+	var m map[string]int
+
+	// panic: assignment to entry in nil map
+	m["key"] = 1
+}
+
+// 陷阱2：nil map 读取
+func NilMapTrap2() {
+	var m map[string]int
+
+	// nil map 可以读取，返回零值
+	val := m["key"]
+	i18n.Printf("读取 nil map: %d\n", val) // 0
+
+	// 检查键是否存在
+	val, ok := m["key"]
+	i18n.Printf("值: %d, 存在: %v\n", val, ok) // 0, false
+}
+
+// pageStats 的零值中 visits 是 nil map
+//
+//readme:wrong
+type pageStats struct {
+	visits map[string]int
+}
+
+// 陷阱3：结构体中的 map 字段没有初始化
+//
+//readme:wrong
+func NilMapTrap3() {
+	var stats pageStats // 零值，visits 字段是 nil
+
+	// panic: assignment to entry in nil map
+	stats.visits["/"]++
+}
+
+// 陷阱4：调用方传入 nil map
+//
+//readme:wrong
+func NilMapTrap4() {
+	recordVisit(nil, "/") // 忘记创建 map
+}
+
+// recordVisit 假设调用方传入的 map 已经创建
+//
+//readme:wrong
+func recordVisit(visits map[string]int, page string) {
+	// panic: assignment to entry in nil map
+	visits[page]++
+}
+
+// 正确方式1：初始化 map
+//
+//readme:correct
+func NilMapCorrectWay() {
+	// 方式1：使用 make
+	m1 := make(map[string]int)
+	m1["key"] = 1
+	i18n.Printf("m1: %v\n", m1)
+
+	// 方式2：使用字面量
+	m2 := map[string]int{
+		"key": 1,
+	}
+	i18n.Printf("m2: %v\n", m2)
+
+	// 方式3：声明时初始化
+	var m3 map[string]int = make(map[string]int)
+	m3["key"] = 1
+	i18n.Printf("m3: %v\n", m3)
+}
+
+// 正确方式2：检查 map 是否为 nil
+//
+//readme:correct
+func NilMapCorrectWay2() {
+	var m map[string]int
+
+	// 在使用前检查并初始化
+	if m == nil {
+		m = make(map[string]int)
+	}
+
+	m["key"] = 1
+	i18n.Printf("m: %v\n", m)
+}
+
+// 实际应用：map 作为函数参数
+func ProcessMap(m map[string]int) {
+	// 如果传入 nil map，需要检查
+	if m == nil {
+		m = make(map[string]int)
+	}
+	m["processed"] = 1
+}
+
+// 注意事项
+func nilMapNotes() {
+	// 1. nil map 可以读取，返回零值
+	// 2. nil map 不能写入，会 panic
+	// 3. 使用 make 或字面量初始化 map
+	// 4. 检查 map 是否为 nil 后再写入
+	// 5. 删除 nil map 的元素不会 panic（但也没有效果）
+
+	var m map[string]int
+	delete(m, "key") // 不会 panic，但也没有效果
+}
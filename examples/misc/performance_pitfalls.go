@@ -0,0 +1,226 @@
+package misc
+
+import (
+	"fmt"
+	"strings"
+
+	"go-trap/internal/i18n"
+)
+
+// 陷阱：性能问题
+// 问题：常见的性能陷阱导致程序运行缓慢
+
+// PerfDemo 依次运行“性能问题”的各个示例
+func PerfDemo() {
+	i18n.Println("=== 陷阱示例：性能问题 ===")
+
+	// 陷阱1：字符串拼接
+	i18n.Println("\n陷阱1：字符串拼接")
+	PerfTrap1()
+
+	// 陷阱2：切片预分配
+	i18n.Println("\n陷阱2：切片预分配")
+	PerfTrap2()
+
+	// 陷阱3：不必要的内存分配
+	i18n.Println("\n陷阱3：不必要的内存分配")
+	PerfTrap3()
+
+	// 陷阱4：大结构体按值传递
+	i18n.Println("\n陷阱4：大结构体按值传递")
+	PerfTrap4()
+
+	// 陷阱5：先用 Sprintf 生成字符串再写入 Builder
+	i18n.Println("\n陷阱5：先用 Sprintf 生成字符串再写入 Builder")
+	PerfTrap5()
+
+	// 陷阱6：循环中重复的类型断言
+	i18n.Println("\n陷阱6：循环中重复的类型断言")
+	PerfTrap6()
+
+	// 正确方式
+	i18n.Println("\n正确方式：")
+	PerfCorrectWay()
+}
+
+// 陷阱1：使用 + 拼接字符串
+//
+//readme:wrong
+func PerfTrap1() {
+	// 错误：每次拼接都创建新字符串
+	var result string
+	for i := 0; i < 1000; i++ {
+		result += fmt.Sprintf("%d ", i) // 低效
+	}
+	_ = result
+}
+
+// 陷阱2：切片未预分配容量
+//
+//readme:wrong
+func PerfTrap2() {
+	// 错误：频繁扩容
+	var slice []int
+	for i := 0; i < 1000; i++ {
+		slice = append(slice, i) // 可能多次扩容
+	}
+	_ = slice
+}
+
+// 陷阱3：不必要的内存分配
+func PerfTrap3() {
+	// 错误：在循环中创建大对象
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, 1024*1024) // 每次循环都分配
+		_ = data
+	}
+}
+
+// 正确方式1：使用 strings.Builder
+//
+//readme:correct
+func PerfCorrectWay() {
+	// 正确：使用 strings.Builder
+	var builder strings.Builder
+	builder.Grow(10000) // 预分配容量
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&builder, "%d ", i) // 直接写入 builder，不生成临时字符串
+	}
+	result := builder.String()
+	_ = result
+}
+
+// 正确方式2：预分配切片容量
+//
+//readme:correct
+func PerfCorrectWay2() {
+	// 正确：预分配容量
+	slice := make([]int, 0, 1000) // 预分配容量
+	for i := 0; i < 1000; i++ {
+		slice = append(slice, i) // 不会扩容
+	}
+	_ = slice
+}
+
+// 正确方式3：复用对象
+func PerfCorrectWay3() {
+	// 正确：在循环外分配
+	data := make([]byte, 1024*1024)
+	for i := 0; i < 1000; i++ {
+		// 复用 data
+		_ = data
+	}
+}
+
+// 陷阱4：大结构体按值传递
+func PerfTrap4() {
+	// 错误：每次调用都复制 8KB 的结构体
+	var s LargeStruct
+	sum := 0
+	for i := 0; i < 1000; i++ {
+		sum += processLarge(s)
+	}
+	_ = sum
+}
+
+// 正确方式4：大结构体按指针传递
+func PerfCorrectWay4() {
+	// 正确：只复制一个指针
+	var s LargeStruct
+	sum := 0
+	for i := 0; i < 1000; i++ {
+		sum += processLargePtr(&s)
+	}
+	_ = sum
+}
+
+// 陷阱5：用了 strings.Builder，却先用 Sprintf 生成临时字符串再写入
+//
+//readme:wrong
+func PerfTrap5() {
+	// 错误：每次循环都分配一个临时字符串，再复制到 builder 中
+	var builder strings.Builder
+	builder.Grow(10000)
+	for i := 0; i < 1000; i++ {
+		builder.WriteString(fmt.Sprintf("%d ", i))
+	}
+	result := builder.String()
+	_ = result
+}
+
+// 陷阱6：在循环中重复类型断言
+// 单次断言很便宜，每次循环都要比较类型并取出值，只有循环体很小时才看得出差别，
+// 收益有限且随机器波动，是否改写以 gotrap bench 的实测结果为准
+func PerfTrap6() {
+	// 每次循环都做一次类型断言
+	sum := 0
+	for j := 0; j < 1000; j++ {
+		sum += perfValue.(int)
+	}
+	_ = sum
+}
+
+// 正确方式6：在循环外断言一次
+func PerfCorrectWay6() {
+	intVal := perfValue.(int)
+	sum := 0
+	for j := 0; j < 1000; j++ {
+		sum += intVal
+	}
+	_ = sum
+}
+
+// perfValue 是存放在接口中的值，放在包级变量中避免编译器在编译期确定它的类型
+var perfValue interface{} = 42
+
+// LargeStruct 是一个 8KB 的大结构体
+type LargeStruct struct {
+	data [1000]int
+}
+
+// processLarge 按值接收大结构体。
+// 禁止内联，避免编译器省掉复制，和跨包调用时的情况一致
+//
+//go:noinline
+func processLarge(s LargeStruct) int {
+	return s.data[0]
+}
+
+// processLargePtr 按指针接收大结构体
+//
+//go:noinline
+func processLargePtr(s *LargeStruct) int {
+	return s.data[0]
+}
+
+// 其他性能陷阱
+func PerfOtherPitfalls() {
+	// 1. 频繁的 map 查找
+	m := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		val := m["key"] // 每次都查找
+		_ = val
+	}
+
+	// 正确：缓存查找结果
+	val, ok := m["key"]
+	if ok {
+		// 使用 val
+		_ = val
+	}
+
+	// 2. 循环中的类型断言：单次断言只比较一次类型指针，只有热点循环里才可能测出差别，见 PerfTrap6 和 PerfCorrectWay6
+	// 3. 大结构体按值传递：见 PerfTrap4 和 PerfCorrectWay4
+}
+
+// 性能优化建议
+func perfOptimizationTips() {
+	// 1. 使用 strings.Builder 而不是 + 拼接字符串
+	// 2. 预分配切片和 map 的容量
+	// 3. 避免不必要的内存分配
+	// 4. 复用对象而不是创建新对象
+	// 5. 大结构体使用指针传递
+	// 6. 使用 sync.Pool 复用临时对象
+	// 7. 热点循环中的类型断言可以提到循环外，先用基准测试确认收益
+	// 8. 使用 pprof 分析性能瓶颈
+}
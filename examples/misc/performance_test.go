@@ -0,0 +1,78 @@
+package misc
+
+import "testing"
+
+// 每个陷阱和对应的正确方式各有一个基准测试，名称为 Benchmark 加上示例函数名，
+// gotrap bench 按这个约定把结果配对并计算比值。
+//
+//	go test -run '^$' -bench Perf -benchmem ./examples/misc
+
+func BenchmarkPerfTrap1(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfTrap1()
+	}
+}
+
+func BenchmarkPerfCorrectWay(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfCorrectWay()
+	}
+}
+
+func BenchmarkPerfTrap2(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfTrap2()
+	}
+}
+
+func BenchmarkPerfCorrectWay2(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfCorrectWay2()
+	}
+}
+
+func BenchmarkPerfTrap3(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfTrap3()
+	}
+}
+
+func BenchmarkPerfCorrectWay3(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfCorrectWay3()
+	}
+}
+
+func BenchmarkPerfTrap4(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfTrap4()
+	}
+}
+
+func BenchmarkPerfCorrectWay4(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfCorrectWay4()
+	}
+}
+
+func BenchmarkPerfTrap6(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfTrap6()
+	}
+}
+
+func BenchmarkPerfCorrectWay6(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PerfCorrectWay6()
+	}
+}
@@ -0,0 +1,5 @@
+// Package pointers 包含指针（Pointers）相关的陷阱示例：nil 指针解引用、返回局部变量指针、指针接收者和切片中的指针。
+//
+// 每个示例文件对应 registry 中的一个陷阱，XxxDemo 函数按顺序运行该文件中的所有示例，
+// examples/cmd 下的同名命令只是调用它的薄封装。
+package pointers
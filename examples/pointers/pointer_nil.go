@@ -0,0 +1,71 @@
+package pointers
+
+import "go-trap/internal/i18n"
+
+// 陷阱：Nil 指针解引用
+// 问题：在使用指针前未检查是否为 nil，导致程序 panic
+
+// NilDemo 依次运行“Nil 指针解引用”的各个示例
+func NilDemo() {
+	i18n.Println("=== 陷阱示例：Nil 指针解引用 ===")
+
+	// 错误示例：直接使用 nil 指针
+	i18n.Println("\n错误示例：")
+	// NilWrongWay() // 会 panic，用 gotrap crash pointer_nil 在子进程中运行
+
+	// 正确示例：检查 nil
+	i18n.Println("\n正确示例：")
+	NilCorrectWay()
+}
+
+// 错误方式：直接解引用可能为 nil 的指针
+//
+//readme:wrong
+func NilWrongWay() {
+	var p *int
+	i18n.Println(*p) // panic: runtime error: invalid memory address or nil pointer dereference
+}
+
+// 正确方式：在使用前检查 nil
+//
+//readme:correct
+func NilCorrectWay() {
+	var p *int
+
+	// 方式1：检查 nil
+	if p != nil {
+		i18n.Println(*p)
+	} else {
+		i18n.Println("指针为 nil，不能解引用")
+	}
+
+	// 方式2：使用函数返回指针
+	p = GetPointer()
+	if p != nil {
+		i18n.Printf("指针值: %d\n", *p)
+	}
+}
+
+func GetPointer() *int {
+	val := 42
+	return &val
+}
+
+// 常见场景：结构体指针方法
+type Person struct {
+	Name string
+	Age  int
+}
+
+func (p *Person) GetName() string {
+	// 如果 p 为 nil，这里会 panic
+	// 应该先检查 p != nil
+	return p.Name
+}
+
+func SafeGetName(p *Person) string {
+	if p == nil {
+		return i18n.T("未知")
+	}
+	return p.Name
+}
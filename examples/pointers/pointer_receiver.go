@@ -1,20 +1,25 @@
-package main
+package pointers
 
-import "fmt"
+import "go-trap/internal/i18n"
 
 // 陷阱：指针接收者 vs 值接收者
 // 问题：混淆指针接收者和值接收者的使用场景，导致意外的行为
 
+//readme:wrong
 type Counter struct {
 	value int
 }
 
 // 值接收者：不会修改原始值
+//
+//readme:wrong
 func (c Counter) IncrementByValue() {
 	c.value++ // 只修改副本
 }
 
 // 指针接收者：会修改原始值
+//
+//readme:correct
 func (c *Counter) IncrementByPointer() {
 	c.value++ // 修改原始值
 }
@@ -24,36 +29,37 @@ func (c Counter) GetValue() int {
 	return c.value
 }
 
-func main() {
-	fmt.Println("=== 陷阱示例：指针接收者 vs 值接收者 ===")
-	
+// ReceiverDemo 依次运行“指针接收者 vs 值接收者”的各个示例
+func ReceiverDemo() {
+	i18n.Println("=== 陷阱示例：指针接收者 vs 值接收者 ===")
+
 	// 示例1：值接收者不会修改原始值
-	fmt.Println("\n示例1：值接收者")
+	i18n.Println("\n示例1：值接收者")
 	c1 := Counter{value: 0}
 	c1.IncrementByValue()
-	fmt.Printf("调用值接收者后: %d\n", c1.GetValue()) // 仍然是 0
-	
+	i18n.Printf("调用值接收者后: %d\n", c1.GetValue()) // 仍然是 0
+
 	// 示例2：指针接收者会修改原始值
-	fmt.Println("\n示例2：指针接收者")
+	i18n.Println("\n示例2：指针接收者")
 	c2 := Counter{value: 0}
 	c2.IncrementByPointer()
-	fmt.Printf("调用指针接收者后: %d\n", c2.GetValue()) // 变为 1
-	
+	i18n.Printf("调用指针接收者后: %d\n", c2.GetValue()) // 变为 1
+
 	// 示例3：值类型调用指针接收者方法（Go 会自动转换）
-	fmt.Println("\n示例3：值类型调用指针接收者方法")
+	i18n.Println("\n示例3：值类型调用指针接收者方法")
 	c3 := Counter{value: 0}
-	c3.IncrementByPointer() // Go 会自动转换为 (&c3).IncrementByPointer()
-	fmt.Printf("值类型调用指针接收者后: %d\n", c3.GetValue()) // 变为 1
-	
+	c3.IncrementByPointer()                         // Go 会自动转换为 (&c3).IncrementByPointer()
+	i18n.Printf("值类型调用指针接收者后: %d\n", c3.GetValue()) // 变为 1
+
 	// 示例4：指针类型调用值接收者方法（Go 会自动解引用）
-	fmt.Println("\n示例4：指针类型调用值接收者方法")
+	i18n.Println("\n示例4：指针类型调用值接收者方法")
 	c4 := &Counter{value: 0}
-	c4.IncrementByValue() // Go 会自动转换为 (*c4).IncrementByValue()
-	fmt.Printf("指针类型调用值接收者后: %d\n", c4.GetValue()) // 仍然是 0
-	
+	c4.IncrementByValue()                           // Go 会自动转换为 (*c4).IncrementByValue()
+	i18n.Printf("指针类型调用值接收者后: %d\n", c4.GetValue()) // 仍然是 0
+
 	// 陷阱：接口实现
-	fmt.Println("\n陷阱：接口实现")
-	demonstrateInterface()
+	i18n.Println("\n陷阱：接口实现")
+	DemonstrateInterface()
 }
 
 // 接口实现陷阱
@@ -88,22 +94,21 @@ func (p *PointerCounter) GetValue() int {
 	return p.value
 }
 
-func demonstrateInterface() {
+func DemonstrateInterface() {
 	// 值类型可以实现接口
 	var v1 Incrementer = ValueCounter{value: 0}
 	v1.Increment()
-	fmt.Printf("值接收者接口: %d\n", v1.GetValue()) // 仍然是 0
-	
+	i18n.Printf("值接收者接口: %d\n", v1.GetValue()) // 仍然是 0
+
 	// 指针类型也可以实现接口
 	var v2 Incrementer = &PointerCounter{value: 0}
 	v2.Increment()
-	fmt.Printf("指针接收者接口: %d\n", v2.GetValue()) // 变为 1
-	
+	i18n.Printf("指针接收者接口: %d\n", v2.GetValue()) // 变为 1
+
 	// 陷阱：值类型不能赋值给需要指针接收者的接口
 	// var v3 Incrementer = PointerCounter{value: 0} // 编译错误！
 	// 必须使用指针：
 	var v3 Incrementer = &PointerCounter{value: 0}
 	v3.Increment()
-	fmt.Printf("指针接收者接口（正确用法）: %d\n", v3.GetValue())
+	i18n.Printf("指针接收者接口（正确用法）: %d\n", v3.GetValue())
 }
-
@@ -0,0 +1,120 @@
+// Package bench 运行示例包中的基准测试，并解析 go test -bench -benchmem 的输出。
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-trap/registry"
+)
+
+// Result 是一个基准测试的结果，-count 大于 1 时为多次运行的平均值
+type Result struct {
+	Name        string // 去掉 "Benchmark" 前缀和 GOMAXPROCS 后缀的名称，即示例函数名
+	Runs        int    // 运行次数（-count）
+	NsPerOp     float64
+	BytesPerOp  float64
+	AllocsPerOp float64
+}
+
+// Metric 返回指定指标的值
+func (r Result) Metric(m registry.BenchMetric) float64 {
+	switch m {
+	case registry.BytesPerOp:
+		return r.BytesPerOp
+	case registry.AllocsPerOp:
+		return r.AllocsPerOp
+	default:
+		return r.NsPerOp
+	}
+}
+
+// Options 控制基准测试的运行方式
+type Options struct {
+	Dir       string // 执行 go test 的目录（模块根目录）
+	Benchtime string // 传给 -benchtime，空字符串表示使用默认值
+	Count     int    // 传给 -count，<= 1 表示只运行一次
+}
+
+// Run 在包 pkg 中运行名为 "Benchmark" + 函数名 的基准测试，返回原始输出。
+// go test 失败时返回的错误中包含它的输出。
+func Run(ctx context.Context, pkg string, funcs []string, opts Options) ([]byte, error) {
+	quoted := make([]string, len(funcs))
+	for i, f := range funcs {
+		quoted[i] = regexp.QuoteMeta(f)
+	}
+	args := []string{"test", "-run", "^$", "-benchmem",
+		"-bench", "^Benchmark(" + strings.Join(quoted, "|") + ")$"}
+	if opts.Benchtime != "" {
+		args = append(args, "-benchtime", opts.Benchtime)
+	}
+	if opts.Count > 1 {
+		args = append(args, "-count", strconv.Itoa(opts.Count))
+	}
+	args = append(args, pkg)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = opts.Dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("go test %s: %w\n%s", pkg, err, out)
+	}
+	return out, nil
+}
+
+var lineRe = regexp.MustCompile(`^Benchmark(\S+?)(?:-\d+)?\s+(\d+)\s+(.*)$`)
+
+// Parse 解析 go test -bench 的输出，按示例函数名返回结果。
+// 同一个基准测试出现多次时（-count），各项指标取平均值。
+func Parse(out []byte) map[string]Result {
+	results := make(map[string]Result)
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		m := lineRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		r := results[m[1]]
+		r.Name = m[1]
+		// 指标部分形如 "684872 ns/op	 2043605 B/op	 2744 allocs/op"
+		fields := strings.Fields(m[3])
+		for i := 0; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			switch registry.BenchMetric(fields[i+1]) {
+			case registry.NsPerOp:
+				r.NsPerOp = (r.NsPerOp*float64(r.Runs) + v) / float64(r.Runs+1)
+			case registry.BytesPerOp:
+				r.BytesPerOp = (r.BytesPerOp*float64(r.Runs) + v) / float64(r.Runs+1)
+			case registry.AllocsPerOp:
+				r.AllocsPerOp = (r.AllocsPerOp*float64(r.Runs) + v) / float64(r.Runs+1)
+			}
+		}
+		r.Runs++
+		results[m[1]] = r
+	}
+	return results
+}
+
+// Ratio 返回错误写法与正确写法在指标 m 上的比值。
+// 正确写法为 0 而错误写法不为 0 时返回 +Inf，两者都为 0 时返回 1。
+func Ratio(wrong, correct Result, m registry.BenchMetric) float64 {
+	w, c := wrong.Metric(m), correct.Metric(m)
+	switch {
+	case c == 0 && w == 0:
+		return 1
+	case c == 0:
+		return math.Inf(1)
+	default:
+		return w / c
+	}
+}
@@ -0,0 +1,85 @@
+package bench
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-trap/registry"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want map[string]Result
+	}{
+		{
+			name: "没有基准测试",
+			out:  "PASS\nok  \tgo-trap/examples/misc\t0.01s\n",
+			want: map[string]Result{},
+		},
+		{
+			name: "去掉 GOMAXPROCS 后缀",
+			out: "goos: linux\ngoarch: amd64\n" +
+				"BenchmarkPerfTrap1-8   \t    1747\t    684872 ns/op\t 2043605 B/op\t    2744 allocs/op\n" +
+				"BenchmarkPerfCorrectWay-8\t   53244\t     22560 ns/op\t   21240 B/op\t      14 allocs/op\n" +
+				"PASS\n",
+			want: map[string]Result{
+				"PerfTrap1":      {Name: "PerfTrap1", Runs: 1, NsPerOp: 684872, BytesPerOp: 2043605, AllocsPerOp: 2744},
+				"PerfCorrectWay": {Name: "PerfCorrectWay", Runs: 1, NsPerOp: 22560, BytesPerOp: 21240, AllocsPerOp: 14},
+			},
+		},
+		{
+			name: "没有后缀和内存指标",
+			out:  "BenchmarkPerfTrap6 \t 1000000\t      1152.5 ns/op\n",
+			want: map[string]Result{
+				"PerfTrap6": {Name: "PerfTrap6", Runs: 1, NsPerOp: 1152.5},
+			},
+		},
+		{
+			name: "-count 多次运行取平均值",
+			out: "BenchmarkPerfTrap3-4\t100\t300 ns/op\t64 B/op\t1 allocs/op\n" +
+				"BenchmarkPerfTrap3-4\t100\t100 ns/op\t64 B/op\t1 allocs/op\n" +
+				"BenchmarkPerfTrap3-4\t100\t200 ns/op\t32 B/op\t4 allocs/op\n",
+			want: map[string]Result{
+				"PerfTrap3": {Name: "PerfTrap3", Runs: 3, NsPerOp: 200, BytesPerOp: 160.0 / 3, AllocsPerOp: 2},
+			},
+		},
+		{
+			name: "忽略其他输出",
+			out:  "BenchmarkPerfTrap2\n--- FAIL: BenchmarkPerfTrap4\nBenchmarks are fun\n",
+			want: map[string]Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse([]byte(tt.out))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		name           string
+		wrong, correct Result
+		metric         registry.BenchMetric
+		want           float64
+	}{
+		{"ns/op", Result{NsPerOp: 300}, Result{NsPerOp: 100}, registry.NsPerOp, 3},
+		{"B/op", Result{NsPerOp: 1, BytesPerOp: 64}, Result{NsPerOp: 2, BytesPerOp: 128}, registry.BytesPerOp, 0.5},
+		{"allocs/op", Result{AllocsPerOp: 10}, Result{AllocsPerOp: 2}, registry.AllocsPerOp, 5},
+		{"正确写法为 0", Result{AllocsPerOp: 3}, Result{}, registry.AllocsPerOp, math.Inf(1)},
+		{"都为 0", Result{}, Result{}, registry.BytesPerOp, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Ratio(tt.wrong, tt.correct, tt.metric); got != tt.want {
+				t.Errorf("Ratio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
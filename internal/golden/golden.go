@@ -0,0 +1,183 @@
+// Package golden 把示例的输出与 testdata/golden/<语言> 下记录的期望输出进行比较。
+//
+// 比较前会按 registry 中的 OutputRule 对输出进行规范化，
+// 使 goroutine 调度等不确定因素不影响结果。
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go-trap/internal/i18n"
+	"go-trap/registry"
+)
+
+// Dir 是 golden 文件相对于仓库根目录的位置
+const Dir = "testdata/golden"
+
+// floatHeader 分隔规范化输出中位置不固定的行
+const floatHeader = "--- 位置不固定的行 ---"
+
+// Path 返回陷阱在语言 lang 下的 golden 文件路径，每种语言的输出分别记录
+func Path(root string, lang i18n.Lang, id string) string {
+	return filepath.Join(root, Dir, string(lang), id+".golden")
+}
+
+// Normalize 按规则规范化示例输出，lang 是输出的语言，决定分隔位置不固定的行的标题
+func Normalize(lang i18n.Lang, out []byte, rules []registry.OutputRule) (string, error) {
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+
+	// 先取出位置不固定的行，避免它们影响分段
+	var floating []string
+	for _, r := range rules {
+		if r.Mode != registry.Float {
+			continue
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return "", err
+		}
+		kept := lines[:0]
+		for _, l := range lines {
+			if re.MatchString(l) {
+				floating = append(floating, l)
+			} else {
+				kept = append(kept, l)
+			}
+		}
+		lines = kept
+	}
+
+	sections := split(lines)
+	for _, r := range rules {
+		if r.Mode == registry.Float {
+			continue
+		}
+		if r.Section < 0 || r.Section >= len(sections) {
+			return "", i18n.Errorf("输出只有 %d 段，规则引用了第 %d 段", len(sections), r.Section)
+		}
+		// 段落的第一行是标题，规则只作用于标题之后的内容
+		sec := sections[r.Section]
+		if len(sec) == 0 {
+			continue
+		}
+		head, body := sec[0], sec[1:]
+		switch r.Mode {
+		case registry.Sorted:
+			slices.Sort(body)
+		case registry.Set:
+			slices.Sort(body)
+			body = slices.Compact(body)
+		case registry.Scrub:
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return "", err
+			}
+			for i, l := range body {
+				body[i] = re.ReplaceAllString(l, r.Replace)
+			}
+		}
+		sections[r.Section] = append([]string{head}, body...)
+	}
+
+	var b strings.Builder
+	for i, sec := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		for _, l := range sec {
+			b.WriteString(l)
+			b.WriteString("\n")
+		}
+	}
+	if len(floating) > 0 {
+		slices.Sort(floating)
+		b.WriteString("\n" + i18n.Translate(lang, floatHeader) + "\n")
+		for _, l := range floating {
+			b.WriteString(l)
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// split 按空行把输出分段，段内不包含空行
+func split(lines []string) [][]string {
+	sections := [][]string{nil}
+	for _, l := range lines {
+		if l == "" {
+			sections = append(sections, nil)
+			continue
+		}
+		last := len(sections) - 1
+		sections[last] = append(sections[last], l)
+	}
+	return sections
+}
+
+// Read 读取 golden 文件
+func Read(root string, lang i18n.Lang, id string) (string, error) {
+	data, err := os.ReadFile(Path(root, lang, id))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Write 写入 golden 文件，必要时创建目录
+func Write(root string, lang i18n.Lang, id, content string) error {
+	p := Path(root, lang, id)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, []byte(content), 0o644)
+}
+
+// Diff 逐行比较期望输出和实际输出，一致时返回空字符串。
+// 结果中 "-" 开头的行只出现在期望输出中，"+" 开头的行只出现在实际输出中。
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	a := strings.Split(strings.TrimRight(want, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(got, "\n"), "\n")
+
+	// lcs[i][j] 是 a[i:] 和 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			fmt.Fprintf(&out, "+ %s\n", b[j])
+			j++
+		default:
+			fmt.Fprintf(&out, "- %s\n", a[i])
+			i++
+		}
+	}
+	if out.Len() == 0 {
+		return i18n.T("（只有末尾的空行不同）\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,122 @@
+package golden
+
+import (
+	"strings"
+	"testing"
+
+	"go-trap/internal/i18n"
+	"go-trap/registry"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		out   string
+		rules []registry.OutputRule
+		want  string
+	}{
+		{
+			name: "没有规则",
+			out:  "a\nb\n\nc\n\n\n",
+			want: "a\nb\n\nc\n",
+		},
+		{
+			name:  "Sorted 只排序标题之后的行",
+			out:   "标题\nc\na\nb\na\n\n第二段\nz\ny\n",
+			rules: []registry.OutputRule{{Mode: registry.Sorted, Section: 0}},
+			want:  "标题\na\na\nb\nc\n\n第二段\nz\ny\n",
+		},
+		{
+			name:  "Set 排序并去重",
+			out:   "第一段\n\n标题\nb\na\nb\n",
+			rules: []registry.OutputRule{{Mode: registry.Set, Section: 1}},
+			want:  "第一段\n\n标题\na\nb\n",
+		},
+		{
+			name:  "Scrub 替换标题之后的行",
+			out:   "耗时 12ms\n耗时 15ms\n耗时 3ms\n",
+			rules: []registry.OutputRule{{Mode: registry.Scrub, Section: 0, Pattern: `\d+ms`, Replace: "Nms"}},
+			want:  "耗时 12ms\n耗时 Nms\n耗时 Nms\n",
+		},
+		{
+			name: "Float 取出的行不影响分段",
+			out:  "标题\nb\ngoroutine running\na\n\n结束\n",
+			rules: []registry.OutputRule{
+				{Mode: registry.Float, Pattern: `^goroutine running$`},
+				{Mode: registry.Sorted, Section: 0},
+			},
+			want: "标题\na\nb\n\n结束\n\n" + floatHeader + "\ngoroutine running\n",
+		},
+		{
+			name: "Float 的行排序后放在最后",
+			out:  "x 2\n标题\nx 1\n",
+			rules: []registry.OutputRule{
+				{Mode: registry.Float, Pattern: `^x \d$`},
+			},
+			want: "标题\n\n" + floatHeader + "\nx 1\nx 2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(i18n.Zh, []byte(tt.out), tt.rules)
+			if err != nil {
+				t.Fatalf("Normalize() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeFloatHeaderLang(t *testing.T) {
+	rules := []registry.OutputRule{{Mode: registry.Float, Pattern: `^x$`}}
+	got, err := Normalize(i18n.En, []byte("title\nx\n"), rules)
+	if err != nil {
+		t.Fatalf("Normalize() error: %v", err)
+	}
+	want := "title\n\n" + i18n.Translate(i18n.En, floatHeader) + "\nx\n"
+	if got != want || strings.Contains(got, floatHeader) {
+		t.Errorf("Normalize() = %q\nwant %q", got, want)
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []registry.OutputRule
+	}{
+		{"段落不存在", []registry.OutputRule{{Mode: registry.Sorted, Section: 2}}},
+		{"负的段落", []registry.OutputRule{{Mode: registry.Set, Section: -1}}},
+		{"Scrub 正则无效", []registry.OutputRule{{Mode: registry.Scrub, Pattern: `(`}}},
+		{"Float 正则无效", []registry.OutputRule{{Mode: registry.Float, Pattern: `[`}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Normalize(i18n.Zh, []byte("a\n\nb\n"), tt.rules); err == nil {
+				t.Error("Normalize() 没有返回错误")
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	i18n.SetLang(i18n.Zh)
+	tests := []struct {
+		name      string
+		want, got string
+		diff      string
+	}{
+		{"相同", "a\nb\n", "a\nb\n", ""},
+		{"只有末尾空行不同", "a\n", "a\n\n", "（只有末尾的空行不同）\n"},
+		{"修改一行", "a\nb\nc\n", "a\nx\nc\n", "+ x\n- b\n"},
+		{"增加和删除", "a\nb\n", "b\nc\n", "- a\n+ c\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.want, tt.got); got != tt.diff {
+				t.Errorf("Diff() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,586 @@
+package i18n
+
+// en 是英文消息目录，键是去掉首尾换行的中文原文
+var en = map[string]string{
+	// 各示例共用
+	"错误示例：": "Wrong way:",
+	"正确示例：": "Correct way:",
+	"正确方式：": "Correct way:",
+
+	// examples/internal/demo
+	"只运行指定的示例函数":                   "run only the given example function",
+	"列出可以单独运行的示例函数":                "list the example functions that can be run on their own",
+	"输出语言：zh 或 en，默认按 LANG 环境变量选择": "output language: zh or en, chosen from the LANG environment variable by default",
+	"未知的示例函数: %s":                  "unknown example function: %s",
+	"不支持的语言 %q，可选 zh、en":           "unsupported language %q, expected zh or en",
+
+	// goroutine_closure
+	"=== 陷阱示例：闭包变量捕获 ===": "=== Trap: closure variable capture ===",
+	"错误: i = %d":            "wrong: i = %d",
+	"错误: sum = %d（可能小于 10）": "wrong: sum = %d (may be less than 10)",
+	"正确: val = %d":          "correct: val = %d",
+	"正确: i = %d":            "correct: i = %d",
+
+	// goroutine_wait
+	"=== 陷阱示例：未等待 Goroutine 完成 ===": "=== Trap: not waiting for goroutines to finish ===",
+	"Goroutine %d 完成":               "goroutine %d done",
+	"主程序退出（goroutine 可能未完成）":        "main exits (goroutines may not have finished)",
+	"所有 goroutine 已完成，主程序退出":        "all goroutines finished, main exits",
+
+	// goroutine_leak
+	"=== 陷阱示例：Goroutine 泄漏 ===": "=== Trap: goroutine leak ===",
+	"收到值: %d": "received value: %d",
+	"Goroutine 已启动（但会永远阻塞）": "goroutine started (but it will block forever)",
+	"Goroutine 正常完成":        "goroutine finished normally",
+	"收到退出信号":                "received quit signal",
+	"Goroutine 正常退出":        "goroutine exited normally",
+	"错误示例2：超时返回后发送方永远阻塞":    "Wrong way 2: the sender blocks forever after the caller times out",
+	"正确示例：超时时发送方也能退出":       "Correct way: the sender can exit on timeout too",
+	"泄漏的 goroutine 数量: %d":  "leaked goroutines: %d",
+
+	// waitgroup_error
+	"=== 陷阱示例：WaitGroup 使用错误 ===": "=== Trap: WaitGroup misuse ===",
+	"陷阱1：Add 和 Done 不匹配":          "Trap 1: Add and Done do not match",
+	"陷阱2：在 goroutine 外调用 Done":    "Trap 2: calling Done outside the goroutine",
+	"陷阱3：Add 调用时机错误":              "Trap 3: calling Add at the wrong time",
+	"Goroutine 执行":                "goroutine running",
+	"完成":                          "done",
+	"Goroutine %d 执行":             "goroutine %d running",
+	"所有 goroutine 完成":             "all goroutines done",
+
+	// pointer_nil
+	"=== 陷阱示例：Nil 指针解引用 ===": "=== Trap: nil pointer dereference ===",
+	"指针为 nil，不能解引用":          "pointer is nil, cannot dereference",
+	"指针值: %d":                "pointer value: %d",
+	"未知":                     "unknown",
+
+	// pointer_local
+	"=== 陷阱示例：返回局部变量指针 ===":   "=== Trap: returning a pointer to a local variable ===",
+	"示例：返回局部变量指针（Go 中通常是安全的）": "Example: returning a pointer to a local variable (usually safe in Go)",
+	"示例：返回局部变量的值（更安全）":        "Example: returning the value of a local variable (safer)",
+	"值: %d":  "value: %d",
+	"数组: %v": "array: %v",
+	"切片: %v": "slice: %v",
+
+	// pointer_receiver
+	"=== 陷阱示例：指针接收者 vs 值接收者 ===": "=== Trap: pointer receivers vs value receivers ===",
+	"示例1：值接收者":                   "Example 1: value receiver",
+	"调用值接收者后: %d":                "after calling the value receiver: %d",
+	"示例2：指针接收者":                  "Example 2: pointer receiver",
+	"调用指针接收者后: %d":               "after calling the pointer receiver: %d",
+	"示例3：值类型调用指针接收者方法":           "Example 3: calling a pointer-receiver method on a value",
+	"值类型调用指针接收者后: %d":            "value after calling the pointer receiver: %d",
+	"示例4：指针类型调用值接收者方法":           "Example 4: calling a value-receiver method on a pointer",
+	"指针类型调用值接收者后: %d":            "pointer after calling the value receiver: %d",
+	"陷阱：接口实现":                    "Trap: implementing interfaces",
+	"值接收者接口: %d":                 "interface with value receiver: %d",
+	"指针接收者接口: %d":                "interface with pointer receiver: %d",
+	"指针接收者接口（正确用法）: %d":          "interface with pointer receiver (correct usage): %d",
+
+	// slice_pointer
+	"=== 陷阱示例：切片中的指针问题 ===": "=== Trap: pointers in slices ===",
+	"陷阱1：切片中存储指针，共享同一个变量":   "Trap 1: pointers in a slice share one variable",
+	"陷阱2：切片扩容导致指针失效":        "Trap 2: slice growth leaves pointers stale",
+	"第一个元素: %d":             "first element: %d",
+	"切片第一个元素: %d":           "first element of the slice: %d",
+	"索引 %d 的值: %d":          "value at index %d: %d",
+
+	// interface_nil
+	"=== 陷阱示例：Nil 接口值 ===":        "=== Trap: nil interface values ===",
+	"陷阱1：接口值为 nil，但接口本身不为 nil":    "Trap 1: the value is nil but the interface is not",
+	"陷阱2：nil 指针实现接口":              "Trap 2: a nil pointer implementing an interface",
+	"陷阱3：返回 nil 指针的 error 不为 nil": "Trap 3: an error holding a nil pointer is not nil",
+	"正确方式：检查接口值和类型":               "Correct way: check both the value and the type",
+	"接口不为 nil，可以调用方法":             "interface is not nil, methods can be called",
+	"安全调用":                        "safe call",
+	"接口值或类型为 nil，不能调用":            "interface value or type is nil, cannot call",
+	"错误不为 nil":                    "error is not nil",
+
+	// interface_assertion
+	"=== 陷阱示例：接口类型断言 ===": "=== Trap: interface type assertions ===",
+	"类型断言的两种形式：":          "Two forms of type assertion:",
+	"是 Dog: %s":           "is a Dog: %s",
+	"不是 Dog":              "not a Dog",
+	"是 Cat: %s":           "is a Cat: %s",
+	"不是 Cat":              "not a Cat",
+	"未知类型: %T":            "unknown type: %T",
+	"类型断言成功: %s":          "type assertion succeeded: %s",
+	"不是 Cat 类型":           "not of type Cat",
+	"处理狗: %s":             "handling dog: %s",
+	"处理猫: %s":             "handling cat: %s",
+	"未知动物类型":              "unknown animal type",
+
+	// interface_empty
+	"=== 陷阱示例：空接口的使用 ===": "=== Trap: using the empty interface ===",
+	"陷阱：失去类型安全":           "Trap: losing type safety",
+	"正确方式：使用具体类型或泛型":      "Correct way: use concrete types or generics",
+	"实际应用：JSON 处理":        "In practice: handling JSON",
+	"整数: %v, 类型: %T":      "integer: %v, type: %T",
+	"字符串: %v, 类型: %T":     "string: %v, type: %T",
+	"切片: %v, 类型: %T":      "slice: %v, type: %T",
+	"字符串: %s":             "string: %s",
+	"是字符串: %s":            "is a string: %s",
+	"是整数: %d":             "is an integer: %d",
+	"姓名: %s":              "name: %s",
+	"年龄: %.0f":            "age: %.0f",
+	"使用结构体更安全":            "using a struct is safer",
+	"类型: %v, 种类: %v":      "type: %v, kind: %v",
+	"整数值: %d":             "integer value: %d",
+	"字符串值: %s":            "string value: %s",
+	"切片长度: %d":            "slice length: %d",
+	"其他类型":                "other type",
+
+	// interface_receiver
+	"=== 陷阱示例：Interface 接收者问题 ===": "=== Trap: interface receivers ===",
+	"陷阱1：值接收者 vs 指针接收者":            "Trap 1: value receiver vs pointer receiver",
+	"陷阱2：接口赋值问题":                   "Trap 2: assigning to interfaces",
+	"值接收者: %v":                     "value receiver: %v",
+	"指针类型调用值接收者: %v":               "pointer calling the value receiver: %v",
+	"指针接收者: %v":                    "pointer receiver: %v",
+	"计数: %d":                       "count: %d",
+
+	// channel_close
+	"=== 陷阱示例：未关闭通道导致泄漏 ===": "=== Trap: leaking by not closing a channel ===",
+	"发送: %d": "sent: %d",
+	"接收: %d": "received: %d",
+	"接收完成":   "receiving done",
+	"主程序退出（接收方可能还在等待）": "main exits (the receiver may still be waiting)",
+	"所有操作完成": "all operations done",
+	"消费: %d": "consumed: %d",
+
+	// channel_send_closed
+	"=== 陷阱示例：向已关闭通道发送数据 ===": "=== Trap: sending on a closed channel ===",
+	"错误示例2：由接收方关闭通道":          "Wrong example 2: the receiver closes the channel",
+	"通道已关闭":         "channel closed",
+	"捕获到 panic: %v": "recovered panic: %v",
+
+	// channel_receive_closed
+	"=== 陷阱示例：从已关闭通道读取 ===": "=== Trap: receiving from a closed channel ===",
+	"陷阱：无法区分零值和通道关闭":        "Trap: a zero value cannot be told apart from a closed channel",
+	"正确方式：检查通道状态":           "Correct way: check whether the channel is closed",
+	"接收到: %d":               "received: %d",
+	"通道已关闭，循环退出":            "channel closed, loop exits",
+	"Worker %d 处理任务 %d":     "worker %d handling job %d",
+	"Worker %d 退出":          "worker %d exits",
+	"结果: %d":                "result: %d",
+	"从已关闭通道读取: %d":          "received from the closed channel: %d",
+	"再次读取: %d":              "received again: %d",
+	"值: %d, 通道打开: %v":       "value: %d, channel open: %v",
+	"不会阻塞":                  "does not block",
+
+	// channel_select_default
+	"=== 陷阱示例：Select 的 Default Case ===": "=== Trap: the default case in select ===",
+	"陷阱1：default case 导致非阻塞":             "Trap 1: the default case makes select non-blocking",
+	"陷阱2：for 循环中的 select default 空转":     "Trap 2: a select with default spins in a for loop",
+	"正确方式：去掉 default，阻塞等待":               "Correct way: drop default and block",
+	"正确方式：使用 default 实现超时":               "Correct way: use default for timeouts",
+	"没有数据，立即返回（可能错过数据）":                  "no data, returning immediately (data may be missed)",
+	"发送成功":            "sent",
+	"通道已满，无法发送":       "channel full, cannot send",
+	"没有数据可读":          "no data to read",
+	"超时：没有在指定时间内收到数据": "timeout: no data received in time",
+	"成功: %s":          "success: %s",
+	"占用的 CPU 时间: %v":  "CPU time used: %v",
+	"当前平台无法测量 CPU 时间": "CPU time cannot be measured on this platform",
+	"操作超时":            "operation timed out",
+	"通道已满，跳过":         "channel full, skipping",
+	"接收成功: %d":        "received: %d",
+	"没有数据，跳过":         "no data, skipping",
+	"从 ch1 接收到: %d":   "received from ch1: %d",
+	"从 ch2 接收到: %s":   "received from ch2: %s",
+	"超时":              "timeout",
+	"有数据":             "got data",
+	"没有数据，立即返回":       "no data, returning immediately",
+	"结果":              "result",
+
+	// slice_array
+	"=== 陷阱示例：切片和数组的区别 ===": "=== Trap: slices vs arrays ===",
+	"陷阱1：数组是值类型":            "Trap 1: arrays are values",
+	"陷阱2：切片共享底层数组":          "Trap 2: slices share the underlying array",
+	"陷阱3：切片的 append 行为":     "Trap 3: how append behaves on slices",
+	"限制容量的切片: %v, 容量: %d":   "slice with limited capacity: %v, cap: %d",
+
+	// slice_range_modify
+	"=== 陷阱示例：切片遍历时修改 ===": "=== Trap: modifying a slice while ranging over it ===",
+	"陷阱1：遍历时修改元素（值类型）":     "Trap 1: modifying elements while ranging (value types)",
+	"陷阱2：遍历时添加/删除元素":       "Trap 2: adding or removing elements while ranging",
+	"陷阱3：遍历时修改底层数组":        "Trap 3: modifying the underlying array while ranging",
+	"陷阱4：遍历时追加元素":          "Trap 4: appending elements while ranging",
+	"修改后: %v":              "after modification: %v",
+	"删除偶数后: %v":            "after removing even numbers: %v",
+	"原切片: %v":              "original slice: %v",
+	"新切片: %v":              "new slice: %v",
+	"处理: %d":               "processing: %d",
+	"队列: %v":               "queue: %v",
+
+	// map_concurrent
+	"=== 陷阱示例：Map 的并发读写 ===": "=== Trap: concurrent map reads and writes ===",
+	"正确示例1：使用 Mutex":         "Correct way 1: use a Mutex",
+	"正确示例2：使用 sync.Map":      "Correct way 2: use sync.Map",
+	"读取: %d":                 "read: %d",
+	"读取: %v":                 "read: %v",
+
+	// map_nil_write
+	"=== 陷阱示例：nil map 写入 ===": "=== Trap: writing to a nil map ===",
+	"陷阱1：向 nil map 写入":        "Trap 1: writing to a nil map",
+	"陷阱2：nil map 读取":          "Trap 2: reading from a nil map",
+	"陷阱3：结构体中的 map 字段没有初始化":   "Trap 3: a map field of a struct that was never initialized",
+	"陷阱4：调用方传入 nil map":       "Trap 4: the caller passes a nil map",
+	"读取 nil map: %d":          "read from nil map: %d",
+	"值: %d, 存在: %v":           "value: %d, present: %v",
+
+	// map_key_type
+	"=== 陷阱示例：Map 键类型限制 ===": "=== Trap: restrictions on map key types ===",
+	"陷阱1：使用不可比较的类型作为键":       "Trap 1: using a non-comparable type as key",
+	"陷阱2：使用切片作为键":            "Trap 2: using a slice as key",
+
+	// defer_order
+	"=== 陷阱示例：Defer 的执行顺序 ===": "=== Trap: defer execution order ===",
+	"陷阱1：defer 的参数立即求值":        "Trap 1: defer arguments are evaluated immediately",
+	"陷阱2：defer 的执行顺序（LIFO）":    "Trap 2: defer runs in LIFO order",
+	"陷阱3：defer 修改返回值":          "Trap 3: defer modifying return values",
+	"函数结束:":                    "function end:",
+	"第一个 defer":                "first defer",
+	"第二个 defer":                "second defer",
+	"第三个 defer":                "third defer",
+	"函数执行":                     "function body",
+	"返回值:":                     "return value:",
+	"开始":                       "start",
+	"结束":                       "end",
+	"测试错误":                     "test error",
+	"打开资源":                     "open resource",
+	"清理资源":                     "clean up resource",
+	"使用资源":                     "use resource",
+
+	// error_handling
+	"=== 陷阱示例：错误处理 ===":     "=== Trap: error handling ===",
+	"陷阱1：忽略错误":              "Trap 1: ignoring errors",
+	"陷阱2：错误比较不当":            "Trap 2: comparing errors incorrectly",
+	"陷阱3：错误包装":              "Trap 3: wrapping errors",
+	"错误匹配":                  "error matched",
+	"错误: %v":                "error: %v",
+	"无法处理文件: %v":            "cannot process file: %v",
+	"无法处理文件: %w":            "cannot process file: %w",
+	"打开文件失败: %v":            "failed to open file: %v",
+	"文件打开成功":                "file opened",
+	"是预期的错误":                "it is the expected error",
+	"路径错误: %s":              "path error: %s",
+	"处理失败: %w":              "processing failed: %w",
+	"包装后的错误: %v":            "wrapped error: %v",
+	"原始错误: %v":              "original error: %v",
+	"打开文件失败: %w":            "failed to open file: %w",
+	"验证错误 - 字段: %s, 消息: %s": "validation error - field: %s, message: %s",
+	"不能为空":                  "must not be empty",
+
+	// variable_shadowing
+	"=== 陷阱示例：变量遮蔽 ===": "=== Trap: variable shadowing ===",
+	"陷阱1：短变量声明遮蔽外部变量":   "Trap 1: a short variable declaration shadows an outer variable",
+	"陷阱2：if 语句中的变量遮蔽":   "Trap 2: shadowing in an if statement",
+	"陷阱3：错误处理中的变量遮蔽":    "Trap 3: shadowing in error handling",
+	"内部 x: %d":             "inner x: %d",
+	"外部 x: %d":             "outer x: %d",
+	"外部 err: %v":           "outer err: %v",
+	"外部 file: %s, err: %v": "outer file: %s, err: %v",
+
+	// performance_pitfalls
+	"=== 陷阱示例：性能问题 ===":               "=== Trap: performance pitfalls ===",
+	"陷阱1：字符串拼接":                       "Trap 1: string concatenation",
+	"陷阱2：切片预分配":                       "Trap 2: slice preallocation",
+	"陷阱3：不必要的内存分配":                    "Trap 3: unnecessary allocations",
+	"陷阱4：大结构体按值传递":                    "Trap 4: passing large structs by value",
+	"陷阱5：先用 Sprintf 生成字符串再写入 Builder": "Trap 5: formatting with Sprintf before writing to a Builder",
+	"陷阱6：循环中重复的类型断言":                  "Trap 6: repeated type assertions in a loop",
+
+	// cmd/gotrap
+	"输出语言：zh 或 en，默认由 LANG 等环境变量决定": "output language: zh or en, chosen from LANG and related environment variables by default",
+	"gotrap: 未知的子命令 %q":             "gotrap: unknown subcommand %q",
+	`用法：
+  gotrap [-lang zh|en] <子命令> ...
+
+  gotrap list [-category 分类]
+  gotrap run [-parallel N] [-timeout 时长] [-format text|json|junit] [-o 文件] [示例ID|分类 ...]
+  gotrap show <示例ID>
+  gotrap golden [-update] [-parallel N] [-timeout 时长] [示例ID|分类 ...]
+  gotrap crash [-v] [-parallel N] [-timeout 时长] [示例ID|分类 ...]
+  gotrap race [-v] [-parallel N] [-timeout 时长] [示例ID|分类 ...]
+  gotrap bench [-v] [-benchtime 时长] [-count N] [示例ID|分类 ...]
+  gotrap vet [-v] [示例ID|分类 ...]
+  gotrap readme [-check]
+
+分类：goroutine、pointer、interface、channel、other`: `usage:
+  gotrap [-lang zh|en] <subcommand> ...
+
+  gotrap list [-category category]
+  gotrap run [-parallel N] [-timeout duration] [-format text|json|junit] [-o file] [trapID|category ...]
+  gotrap show <trapID>
+  gotrap golden [-update] [-parallel N] [-timeout duration] [trapID|category ...]
+  gotrap crash [-v] [-parallel N] [-timeout duration] [trapID|category ...]
+  gotrap race [-v] [-parallel N] [-timeout duration] [trapID|category ...]
+  gotrap bench [-v] [-benchtime duration] [-count N] [trapID|category ...]
+  gotrap vet [-v] [trapID|category ...]
+  gotrap readme [-check]
+
+categories: goroutine, pointer, interface, channel, other`,
+	"只列出指定分类的示例":                               "list only the traps in the given category",
+	"ID\t分类\t严重程度\t标题":                         "ID\tcategory\tseverity\ttitle",
+	"同时运行的示例数，1 表示依次运行":                        "number of examples to run at the same time, 1 runs them one by one",
+	"单个示例的运行超时":                                "timeout for running a single example",
+	"输出格式：text、json 或 junit":                   "output format: text, json or junit",
+	"把 json 或 junit 结果写入文件而不是标准输出":             "write json or junit results to a file instead of stdout",
+	"未知的输出格式 %q，可选 text、json、junit":            "unknown output format %q, expected text, json or junit",
+	"-o 只能和 -format json 或 -format junit 一起使用": "-o can only be used with -format json or -format junit",
+	"运行: %s": "run: %s",
+	"共 %d 个示例，通过 %d 个，失败 %d 个":          "%d examples, %d passed, %d failed",
+	"共 %d 个示例，通过 %d 个，失败 %d 个，结果已写入 %s": "%d examples, %d passed, %d failed, results written to %s",
+	"用法：gotrap show <示例ID>":             "usage: gotrap show <trapID>",
+	"未知的示例: %s":                         "unknown trap: %s",
+	"未知的示例或分类: %s":                      "unknown trap or category: %s",
+	"标题:     %s":                        "Title:    %s",
+	"问题:     %s":                        "Problem:  %s",
+	"分类:     %s":                        "Category: %s",
+	"严重程度: %s":                          "Severity: %s",
+	"Go 版本:  %s":                        "Go:       %s",
+	"错误写法: %s":                          "Wrong:    %s",
+	"正确写法: %s":                          "Correct:  %s",
+	"文档:     %s":                        "Docs:     %s",
+	"包:       %s":                       "Package:  %s",
+	"文件:     %s":                        "File:     %s",
+	"运行:     go run %s":                 "Run:      go run %s",
+	"找不到 go.mod，请在仓库目录下运行":              "go.mod not found, run gotrap inside the repository",
+	"用本次的输出重写 golden 文件":                "rewrite the golden files with the current output",
+	"已更新  %s":                           "updated  %s",
+	"FAIL  %s: 没有 golden 文件，请先运行 gotrap golden -update %s": "FAIL  %s: no golden file, run gotrap golden -update %s first",
+	"FAIL  %s: 输出与 golden 文件不一致\n%s":                       "FAIL  %s: output differs from the golden file\n%s",
+	"--- 位置不固定的行 ---":                                      "--- lines in no fixed position ---",
+	"输出完整的崩溃信息和 goroutine 栈":                               "print the full crash message and goroutine stacks",
+	"选中的示例中没有会崩溃的错误示例":                                     "none of the selected traps has a crashing wrong-way example",
+	"共 %d 个崩溃示例，符合预期 %d 个，不符合 %d 个":                        "%d crash examples, %d as expected, %d not",
+	"编译失败":                           "build failed",
+	"超时，没有按预期崩溃":                     "timed out instead of crashing",
+	"没有崩溃（退出码 %d），期望 %s: %s":         "did not crash (exit code %d), expected %s: %s",
+	"崩溃方式是 %s，期望 %s":                 "crashed with %s, expected %s",
+	"崩溃信息是 %q，期望包含 %q":               "crash message is %q, expected it to contain %q",
+	"退出码是 %d，期望 %d":                  "exit code is %d, expected %d",
+	"输出每个数据竞争报告的访问栈和 goroutine 创建位置": "print the access stacks and goroutine creation sites of every data race report",
+	"选中的示例中没有需要竞态检测的函数":              "none of the selected traps has functions to check with the race detector",
+	"\t函数\t期望\t结果\t报告数\t位置":          "\tfunction\texpected\tgot\treports\tlocation",
+	"共 %d 个函数，符合预期 %d 个，不符合 %d 个":    "%d functions, %d as expected, %d not",
+	"有竞争":                                 "racy",
+	"无竞争":                                 "race-free",
+	"异常退出（退出码 %d）":                        "exited abnormally (exit code %d)",
+	"%s 的第 %d 个数据竞争：":                     "data race %[2]d in %[1]s:",
+	"之前的 ":                                "previous ",
+	"%s%s（goroutine %d，地址 %s）":            "%s%s (goroutine %d, address %s)",
+	"goroutine %d（%s）创建于：":                "goroutine %d (%s) created at:",
+	"传给 go test 的 -benchtime，如 1s 或 100x": "-benchtime passed to go test, e.g. 1s or 100x",
+	"每个基准测试运行的次数，结果取平均值":                  "number of times to run each benchmark, results are averaged",
+	"输出 go test 的原始输出":                    "print the raw go test output",
+	"FAIL  %s / %s: 没有找到基准测试 Benchmark%s 或 Benchmark%s": "FAIL  %s / %s: benchmark Benchmark%s or Benchmark%s not found",
+	"INFO  %s / %s: %s 比值 %s（不检查）":                      "INFO  %s / %s: %s ratio %s (not checked)",
+	"FAIL  %s / %s: %s 比值 %s，没有达到期望的 %gx":               "FAIL  %s / %s: %s ratio %s, below the expected %gx",
+	"PASS  %s / %s: %s 比值 %s（期望 >= %gx）":                "PASS  %s / %s: %s ratio %s (expected >= %gx)",
+	"选中的示例中没有基准测试":                                      "none of the selected traps has benchmarks",
+	"共 %d 组对比，达到预期 %d 组，未达到 %d 组，只报告比值 %d 组":            "%d comparisons, %d met the expectation, %d did not, %d reported only",
+	"比值": "ratio",
+	"只检查 README 是否是最新的，不写入": "only check that the READMEs are up to date, do not write them",
+	"已生成  %s": "generated  %s",
+	"%s 是最新的": "%s is up to date",
+	"%s 与生成结果不一致（- 是当前文件，+ 是生成结果），请运行 go run ./cmd/gotrap readme：": "%s differs from the generated content (- is the current file, + is generated), run go run ./cmd/gotrap readme:",
+	"输出每条诊断信息":                 "print every diagnostic",
+	"trapvet 中没有陷阱 %s 的分析器":    "trapvet has no analyzer for trap %s",
+	"选中的示例中没有需要 trapvet 检查的函数": "none of the selected traps has functions to check with trapvet",
+	"\t函数\t期望\t结果\t位置":         "\tfunction\texpected\tresult\tlocation",
+	"加载示例包失败":                  "failed to load the example packages",
+	"报告":                       "reported",
+	"不报告":                      "not reported",
+
+	// internal
+	"退出码 %d": "exit code %d",
+	"通过":     "passed",
+	"输出只有 %d 段，规则引用了第 %d 段": "the output has only %d sections but a rule refers to section %d",
+	"（只有末尾的空行不同）":           "(only trailing blank lines differ)",
+
+	// internal/trapvet
+	"%s（陷阱 %s，见 %s）": "%s (trap %s, see %s)",
+	"、":              ", ",
+	"检查循环中启动的 goroutine 通过闭包修改循环外的变量，以及 Go 1.22 之前捕获循环变量":                                                                             "check goroutines started in a loop that modify variables declared outside the loop through a closure, and capture of loop variables before Go 1.22",
+	"goroutine 捕获了循环变量 %s，Go 1.22 之前所有迭代共享同一个变量，goroutine 读到的可能是循环结束后的值；把 %s 作为参数传给 goroutine":                                        "the goroutine captures loop variable %s; before Go 1.22 all iterations share one variable, so the goroutine may see the value after the loop has finished; pass %s to the goroutine as an argument",
+	"循环中启动的多个 goroutine 同时修改循环外的变量 %s，存在数据竞争；用 sync.Mutex、atomic 保护，或让每个 goroutine 返回自己的结果":                                           "goroutines started in a loop modify %s, declared outside the loop, at the same time, which is a data race; protect it with sync.Mutex or atomic, or let every goroutine return its own result",
+	"检查在循环中启动 goroutine 后既不等待也不通信就返回的函数":                                                                                              "check functions that start goroutines in a loop and return without waiting for or communicating with them",
+	"%s 在循环中启动了 goroutine，却没有等待它们完成就返回，程序退出时这些 goroutine 会被直接终止；用 sync.WaitGroup 等待或通过通道收集结果":                                         "%s starts goroutines in a loop but returns without waiting for them, and they are killed when the program exits; wait with sync.WaitGroup or collect the results through a channel",
+	"检查只阻塞在局部通道上的 goroutine：通道没有传出函数，函数中也没有与之配对的发送、接收或关闭；以及调用方只在带超时的 select 中接收、超时后再没有人接收的无缓冲通道上的发送":                                  "check goroutines that block only on a local channel that does not escape the function and has no matching send, receive or close in it, and sends on unbuffered channels whose caller receives only in a select with a timeout, after which nobody receives",
+	"goroutine 阻塞在向无缓冲通道 %s 发送，%s 没有传出这个函数，函数中也没有其他地方从它接收，这个 goroutine 永远不会退出；确保有接收方，或改用带缓冲的通道":                                       "the goroutine blocks sending on unbuffered channel %s; %s does not escape this function and nothing else in it receives from it, so the goroutine never exits; make sure there is a receiver, or use a buffered channel",
+	"goroutine 阻塞在从通道 %s 接收，%s 没有传出这个函数，函数中也没有其他地方向它发送或关闭它，这个 goroutine 永远不会退出；发送数据或关闭通道，或用 context、done 通道通知 goroutine 退出":           "the goroutine blocks receiving from channel %s; %s does not escape this function and nothing else in it sends on it or closes it, so the goroutine never exits; send a value or close the channel, or tell the goroutine to exit with a context or a done channel",
+	"%s 是无缓冲通道，调用方只在第 %d 行的 select 中接收，select 走了超时等其他分支返回后再也没有人接收，这里的发送会永远阻塞；用 make(%s, 1) 创建带一个缓冲的通道，或在 select 中同时等待 ctx.Done() 再发送": "%s is unbuffered and the caller receives from it only in the select on line %d; once the select takes the timeout or another branch and returns, nobody receives, so this send blocks forever; create the channel with one buffer slot using make(%s, 1), or send in a select that also waits on ctx.Done()",
+	"改为 make(%s, 1)": "change to make(%s, 1)",
+	"检查 sync.WaitGroup 的误用：Add 与 Done 次数不匹配、在启动 goroutine 的函数中直接调用 Done、在 goroutine 内部才调用 Add、按值传递 WaitGroup":                       "check sync.WaitGroup misuse: Add and Done counts that do not match, Done called directly in the function that starts the goroutines, Add called inside the goroutine, and WaitGroups passed by value",
+	"参数 %s 按值传递 sync.WaitGroup，函数中的 Add、Done 作用在副本上，调用方的 Wait 看不到；改为 *sync.WaitGroup":                                               "parameter %s passes sync.WaitGroup by value, so Add and Done in the function act on a copy the caller's Wait never sees; use *sync.WaitGroup",
+	"%s 按值传给了函数，传入的是 WaitGroup 的副本，在副本上调用 Done 不会让这里的 Wait 返回；传 &%s":                                                                "%s is passed to the function by value, which copies the WaitGroup, and calling Done on the copy never releases the Wait here; pass &%s",
+	"%s 在新启动的 goroutine 中才调用 WaitGroup 的 Add，Wait 可能在 Add 之前执行并立即返回；在 go 语句之前调用 Add":                                                "%s calls Add on the WaitGroup only inside the new goroutine, so Wait may run before Add and return immediately; call Add before the go statement",
+	"%s.Add 在 goroutine 内部调用，Wait 可能在 Add 之前执行并立即返回；在 go 语句之前调用 %s.Add":                                                             "%s.Add is called inside the goroutine, so Wait may run before Add and return immediately; call %s.Add before the go statement",
+	"%s.Done 没有在 goroutine 中调用，计数在 goroutine 完成之前就减少了，Wait 可能提前返回；在 goroutine 中调用 defer %s.Done()":                                  "%s.Done is not called in a goroutine, so the counter drops before the goroutine finishes and Wait may return early; call defer %s.Done() in the goroutine",
+	"%s.Add 一共增加了 %d，%s.Done 一共调用了 %d 次：Done 少于 Add 时 Wait 永远阻塞，多于 Add 时 panic: sync: negative WaitGroup counter；让每次 Add 对应一次 Done": "%s.Add adds %d in total but %s.Done is called %d times: with fewer Done calls Wait blocks forever, with more it panics with sync: negative WaitGroup counter; match every Add with a Done",
+	"检查声明后没有赋值、也没有检查 nil 就解引用的指针变量":                                                                                                 "check pointer variables that are dereferenced without being assigned or checked for nil after their declaration",
+	"指针 %s 声明后一直是 nil，这里解引用会 panic: invalid memory address or nil pointer dereference；先让它指向一个值，或在使用前检查 %s != nil":                   "pointer %s is still nil since its declaration and dereferencing it here panics with invalid memory address or nil pointer dereference; point it at a value first, or check %s != nil before using it",
+	"检查把局部变量的地址转换成 uintptr 后返回或保存":                                                                                                  "check addresses of local variables that are converted to uintptr and returned or stored",
+	"局部变量 %s 的地址被转换成 uintptr 保存，GC 不会把 uintptr 当作指针，%s 可能被回收或移动；直接使用 &%s，逃逸分析会把它分配到堆上":                                              "the address of local variable %s is stored as a uintptr, which the GC does not treat as a pointer, so %s may be freed or moved; use &%s directly and escape analysis will allocate it on the heap",
+	"检查 Go 1.22 之前保存循环变量的地址，以及 append 之后继续使用之前取得的切片元素地址":                                                                            "check addresses of loop variables that are stored before Go 1.22, and addresses of slice elements still used after an append",
+	"Go 1.22 之前循环变量 %s 由所有迭代共享，保存的 &%s 都指向同一个变量，循环结束后读到的都是最后的值；在循环体中先用 %s := %s 创建新的变量再取地址":                                         "before Go 1.22 the loop variable %s is shared by all iterations, so every saved &%s points to the same variable and reads after the loop all see the last value; create a new variable with %s := %s in the loop body before taking its address",
+	"%s 是 %s 中元素的地址，第 %d 行的 append 可能让 %s 换到新的底层数组，之后通过 %s 读写的是旧数组中的元素；在 append 之后重新取地址，或保存索引而不是元素的地址":                              "%s is the address of an element of %s; the append on line %d may move %s to a new underlying array, after which reads and writes through %s hit the old array; take the address again after the append, or keep the index instead of the element's address",
+	"检查以 error 等接口类型返回的 nil 指针：一直是 nil 的指针变量、(*T)(nil)，以及只在部分分支中赋值的指针变量":                                                            "check nil pointers returned as error or another interface type: pointer variables that are always nil, (*T)(nil), and pointer variables assigned only on some branches",
+	"%s 作为 %s 返回后接口不是 nil：接口里保存了类型 %s 和一个 nil 值，调用方的 != nil 判断为真；没有值时直接返回 nil":                                                      "%s returned as %s makes a non-nil interface: the interface holds the type %s and a nil value, so the caller's != nil check is true; return nil directly when there is no value",
+	"%s 在这里一直是 nil 指针，作为 %s 返回后接口不是 nil：接口里保存了类型 %s 和一个 nil 值，调用方的 != nil 判断为真；没有值时直接返回 nil":                                        "%s is always a nil pointer here, and returning it as %s makes a non-nil interface: the interface holds the type %s and a nil value, so the caller's != nil check is true; return nil directly when there is no value",
+	"%s 只在部分分支中被赋值，其他路径上它还是 nil 指针，作为 %s 返回后接口不是 nil：接口里保存了类型 %s 和一个 nil 值，调用方的 != nil 判断为真；在没有值的分支直接返回 nil，或把 %s 声明为 %s":           "%s is assigned only on some branches and is still a nil pointer on the others, and returning it as %s makes a non-nil interface: the interface holds the type %s and a nil value, so the caller's != nil check is true; return nil directly on the branches without a value, or declare %s as %s",
+	"直接返回 nil": "return nil directly",
+	"检查没有检查 ok、类型也没有被之前的检查确定的单值类型断言 x.(T)":                                                                                   "check single-value type assertions x.(T) whose ok is not checked and whose type is not established by an earlier check",
+	"类型断言 %s 没有检查是否成功，%s 的动态类型不是 %s 时会 panic: interface conversion；改用 v, ok := %s 并处理 ok 为 false 的情况，需要区分多种类型时用 type switch": "type assertion %s is not checked and panics with interface conversion when the dynamic type of %s is not %s; use v, ok := %s and handle ok being false, or a type switch to tell several types apart",
+	"改为 %s, %s := %s 并检查 %s":    "change to %s, %s := %s and check %s",
+	"检查修改接收者字段的值接收者方法，修改只作用于副本": "check value receiver methods that modify fields of the receiver, which only changes a copy",
+	"%s 是值接收者方法，对 %s 的修改只作用于接收者的副本，调用方看不到；需要修改接收者时使用指针接收者 *%s":                                                                                "%s has a value receiver, so modifying %s only changes a copy of the receiver and the caller never sees it; use a pointer receiver *%s to modify the receiver",
+	"检查先后保存多种具体类型的空接口局部变量":                                                                                                                    "check empty interface local variables that hold several different concrete types",
+	"局部变量 %s 是空接口，先后保存了 %s，取值时只能依靠类型断言，编译器无法检查；使用具体类型、泛型或为每种类型定义单独的变量":                                                                        "local variable %s is an empty interface that holds %s in turn, so reading it relies on type assertions the compiler cannot check; use a concrete type, generics, or a separate variable for each type",
+	"检查把结构体的值赋给接口，而接口方法的值接收者实现会修改接收者，修改通过接口调用后丢失":                                                                                             "check struct values assigned to an interface whose method is implemented with a value receiver that modifies the receiver, so the change is lost when called through the interface",
+	"%s 的值被赋给接口 %s，通过接口调用的 %s 是值接收者方法，修改的是接口中保存的副本；赋值时使用指针 &%s，并把 %s 改为指针接收者":                                                                 "a %s value is assigned to interface %s, and %s called through the interface has a value receiver, so it modifies the copy held by the interface; assign the pointer &%s and give %s a pointer receiver",
+	"检查有接收方等待关闭、却从来没有被关闭的局部通道":                                                                                                                "check local channels whose receivers wait for them to be closed but that are never closed",
+	"通道 %s 从来没有被关闭，用 range 或 v, ok := <-%s 等待关闭的接收方会在数据发送完后永远阻塞；由发送方在发送完成后调用 close(%s)，通常写成 defer close(%s)":                                  "channel %s is never closed, so a receiver waiting for it with range or v, ok := <-%s blocks forever once all values are sent; have the sender call close(%s) when it is done, usually as defer close(%s)",
+	"检查可能发生在 close 之后的发送和 close，以及由接收方关闭通道":                                                                                                   "check sends and closes that may happen after a close, and channels closed by the receiver",
+	"向通道 %s 发送可能发生在 close(%s) 之后，会 panic: send on closed channel；只让发送方在所有发送完成后关闭通道":                                                           "the send on channel %s may happen after close(%s) and panics with send on closed channel; let only the sender close the channel once all sends are done",
+	"通道 %s 可能已经被关闭，再次 close 会 panic: close of closed channel；只在一个地方关闭通道，或用 sync.Once 保证只关闭一次":                                                 "channel %s may already be closed, and closing it again panics with close of closed channel; close the channel in one place only, or use sync.Once to close it exactly once",
+	"接收方关闭了通道 %s，而其他 goroutine 还在向它发送，阻塞中或之后的发送会 panic: send on closed channel；应该由发送方关闭通道，接收方用单独的 done 通道或 context 通知发送方停止":                   "the receiver closes channel %s while other goroutines still send on it, so a blocked or later send panics with send on closed channel; the sender should close the channel, and the receiver should tell the sender to stop with a separate done channel or a context",
+	"%s 只从通道参数 %s 接收却关闭了它，调用方的发送会 panic: send on closed channel；应该由发送方关闭通道，参数可以声明为 <-chan 防止误关":                                               "%s only receives from channel parameter %s but closes it, so sends by the caller panic with send on closed channel; the sender should close the channel, and declaring the parameter as <-chan prevents closing it by mistake",
+	"检查在无条件的 for 循环中用单值接收读取会被关闭的通道":                                                                                                           "check single-value receives in an unconditional for loop from a channel that gets closed",
+	"通道 %s 会被关闭，关闭后 <-%s 立即返回零值，循环无法区分零值和关闭；用 v, ok := <-%s 检查 ok，或改用 for v := range %s":                                                      "channel %s gets closed, after which <-%s returns the zero value immediately and the loop cannot tell a zero value from a closed channel; check ok with v, ok := <-%s, or use for v := range %s",
+	"检查 for 循环中带有空的或只有一条简单语句的 default 分支的 select：循环中没有其他阻塞操作时，没有分支就绪就立即进入下一次迭代，空转占满一个 CPU 核心":                                                 "checks for selects in for loops whose default case is empty or a single trivial statement: with no other blocking operation in the loop, the loop starts the next iteration as soon as no case is ready and spins a whole CPU core",
+	"for 循环中的 select 带有空的 default 分支，其他分支都没有就绪时循环立即进入下一次迭代，空转占满一个 CPU 核心；去掉 default 让 select 阻塞等待，等待期间需要做其他事情时加一个 time.Ticker 分支（正确方式4）":      "the select in this for loop has an empty default case: when no other case is ready the loop starts the next iteration at once and spins a whole CPU core; drop default so the select blocks, and add a time.Ticker case if other work must happen while waiting (correct way 4)",
+	"for 循环中的 select 的 default 分支只有一条简单语句，其他分支都没有就绪时循环立即进入下一次迭代，空转占满一个 CPU 核心；去掉 default 让 select 阻塞等待，等待期间需要做其他事情时加一个 time.Ticker 分支（正确方式4）": "the default case of the select in this for loop is a single trivial statement: when no other case is ready the loop starts the next iteration at once and spins a whole CPU core; drop default so the select blocks, and add a time.Ticker case if other work must happen while waiting (correct way 4)",
+	"删除 default 分支": "remove the default case",
+	"检查对子切片 append 后赋给另一个变量，append 可能覆盖原切片的元素":                                              "check appends to a subslice assigned to another variable, which may overwrite elements of the original slice",
+	"%s 是 %s 的子切片，容量足够时 append 会直接覆盖 %s 的元素，%s 与 %s 共享底层数组；用完整切片表达式 %s 限制容量，或先 copy 出独立的切片": "%s is a subslice of %s; when capacity allows, append overwrites elements of %s and %s shares the underlying array with %s; limit the capacity with the full slice expression %s, or copy into an independent slice first",
+	"改为完整切片表达式 %s": "use the full slice expression %s",
+	"检查遍历时修改切片或 map：修改后没有再读取的元素副本、向正在遍历的切片追加或原地删除元素、向正在遍历的 map 添加新键":                                                                  "check slices and maps modified while ranging over them: element copies modified and never read again, appends to or in-place deletes from the slice being ranged over, and new keys added to the map being ranged over",
+	"%s 是 map %s 中值的副本，这里的修改不会写回 map，之后也没有再读取；修改后用 %s[k] = %s 写回，或让 map 保存指针（正确方式2）":                                                  "%s is a copy of a value in map %s, so this change is not written back to the map and is never read again; write it back with %s[k] = %s after the change, or store pointers in the map (correct way 2)",
+	"%s 是 %s 中元素的副本，这里的修改不会写回 %s，之后也没有再读取；用 for i := range %s 按索引修改 %s[i]（正确方式1），或让切片保存指针（正确方式2）":                                     "%s is a copy of an element of %s, so this change is not written back to %s and is never read again; modify %s[i] by index with for i := range %s (correct way 1), or store pointers in the slice (correct way 2)",
+	"range 在开始时就确定了遍历的次数，循环中追加到 %s 的元素不会被遍历；把结果追加到新的切片（正确方式4），需要处理新元素时改用 for i := 0; i < len(%s); i++":                                "range fixes the number of iterations when it starts, so elements appended to %s in the loop are not visited; append results to a new slice (correct way 4), or use for i := 0; i < len(%s); i++ when the new elements must be processed",
+	"遍历 %s 时原地删除元素，后面的元素会前移，紧跟在被删除元素之后的元素会被跳过，索引也不再对应；先收集要删除的索引再从后往前删除（正确方式3），或把保留的元素放进新切片（正确方式4）":                                    "deleting elements in place while ranging over %s shifts later elements forward, so the element right after a deleted one is skipped and indexes no longer match; collect the indexes first and delete from the back (correct way 3), or put the kept elements into a new slice (correct way 4)",
+	"遍历 map %s 时添加新的键，新键是否会在这次遍历中出现是不确定的；先把新的键值对放进另一个 map，遍历结束后再合并":                                                                   "adding new keys while ranging over map %s, it is unspecified whether the new keys show up in this iteration; put new pairs into another map and merge it after the loop",
+	"检查在 goroutine 中写入、同时在其他 goroutine 中读写且没有加锁的局部 map":                                                                               "check local maps written in a goroutine while other goroutines read or write them without a lock",
+	"map %s 在 goroutine 中写入，同时在其他 goroutine 中读写，没有加锁，运行时会 fatal error: concurrent map read and map write；用 sync.Mutex 保护或改用 sync.Map": "map %s is written in a goroutine while other goroutines read or write it without a lock, and the runtime fails with fatal error: concurrent map read and map write; protect it with sync.Mutex or use sync.Map",
+	"检查向只可能是 nil 的 map 写入：声明时没有 make 的变量、零值结构体的 map 字段、每个调用方都传入 nil 的参数":                                                              "check writes to maps that can only be nil: variables declared without make, map fields of zero-valued structs, and parameters every caller passes nil for",
+	"%s 在这里只可能是 nil map，写入会 panic: assignment to entry in nil map；先用 make(%s) 创建 map":                                                 "%s can only be a nil map here, and writing to it panics with assignment to entry in nil map; create the map with make(%s) first",
+	"声明时用 make(%s) 创建 map":       "create the map with make(%s) in the declaration",
+	"写入前用 make(%s) 创建 map":       "create the map with make(%s) before writing",
+	"调用方传入 make(%s) 而不是 nil":     "pass make(%s) instead of nil at the call sites",
+	"检查以接口为键的 map 中使用了不可比较的值作为键": "check non-comparable values used as keys of a map with interface keys",
+	"%s 类型的值不可比较，用作 %s 的键会在运行时 panic: hash of unhashable type；把键转换成字符串等可比较的类型":                         "values of type %s are not comparable, and using one as a key of %s panics at run time with hash of unhashable type; convert the key to a comparable type such as a string",
+	"检查 defer 调用的参数在 defer 之后被修改，以及 defer 中修改了非命名返回值的局部变量":                                             "check arguments of deferred calls that are modified after the defer, and locals returned through unnamed results that are modified in a defer",
+	"defer 调用的参数 %s 在执行 defer 语句时就已求值，之后对 %s 的修改不会反映到延迟调用中；需要最新的值时改为 defer func() { ... }() 在闭包中引用 %s": "argument %s of the deferred call is evaluated when the defer statement runs, and later changes to %s are not seen by the deferred call; to use the latest value, refer to %s in a closure with defer func() { ... }()",
+	"%s 在 defer 中被修改，但函数的返回值不是命名返回值，return %s 时已经复制了结果，defer 中的修改不会影响返回值；需要在 defer 中修改返回值时使用命名返回值":     "%s is modified in a defer, but the result is not named, so return %s has already copied the value and the change in the defer does not affect it; use a named result to modify the return value in a defer",
+	"检查在检查错误之前 defer 调用返回值的方法，循环中一直累积到函数返回才执行的 defer，用 == 比较可能被包装或每次新建的错误，以及用 %v、%s 而不是 %w 包装错误":       "checks for defers of methods on a result before its error is checked, defers in loops that pile up until the function returns, == comparisons against errors that may be wrapped or are created anew on every call, and errors wrapped with %v or %s instead of %w",
+	"%s 返回的错误被忽略了，出错时 %s 可能是 nil，defer %s.%s() 会在 nil 上调用；先检查错误，确认没有出错后再 defer":                        "the error returned by %s is ignored; on failure %s may be nil and defer %s.%s() is called on nil; check the error first and defer only once it succeeded",
+	"defer %s.%s() 在检查 %s 之前，%s 出错时 %s 可能是 nil，延迟调用会在 nil 上执行；把 defer 移到 if %s != nil 检查之后":            "defer %s.%s() comes before %s is checked; when %s fails %s may be nil and the deferred call runs on nil; move the defer after the if %s != nil check",
+	"把 defer 移到错误检查之后": "move the defer after the error check",
+	"defer 要到函数返回时才执行，循环中的 defer %s 会一直累积，每次迭代的资源在整个循环结束之前都不会释放；把循环体放进函数字面量中，让 defer 在每次迭代结束时执行": "defer runs only when the function returns, so defer %s in a loop piles up and no iteration's resources are released until the whole loop ends; move the loop body into a function literal so the defer runs at the end of each iteration",
+	"把循环体放进立即调用的函数字面量中": "move the loop body into an immediately called function literal",
+	"每次调用 %s 都会创建新的错误值，%s 永远不会与它相等；把错误定义成包级变量，再用 errors.Is 比较":                     "every call to %s creates a new error value, so %s is never equal to it; define the error as a package-level variable and compare with errors.Is",
+	"%s 返回的错误可能用 %%w 包装过，%s 只比较最外层的错误，不会检查包装在里面的 %s；改用 %s":                         "the error returned by %s may be wrapped with %%w; %s only compares the outermost error and does not look for %s inside it; use %s instead",
+	"%s 是 error，用 %%%c 格式化只保留了它的信息，调用方无法再用 errors.Is 或 errors.As 检查原始错误；改用 %%w 包装": "%s is an error; formatting it with %%%c keeps only its message, so callers can no longer inspect the original error with errors.Is or errors.As; wrap it with %%w",
+	"改用 %w 包装": "wrap with %w",
+	"检查内层作用域中用 := 声明、遮蔽了外层同名同类型变量的变量，且外层变量在内层作用域结束后还会被读取":                            "check variables declared with := in an inner scope that shadow an outer variable of the same name and type, when the outer variable is read after the inner scope ends",
+	"这里的 := 声明了新的 %s，遮蔽了外层的同名变量；内层作用域结束后读取的仍是外层没有被更新的 %s。要更新外层变量时把 := 改为 =，否则换一个变量名": "this := declares a new %s that shadows the outer variable of the same name; after the inner scope ends, reads still see the outer %s, which was never updated. Change := to = to update the outer variable, or pick a different name",
+	"把 := 改为 =，给外层的变量赋值": "change := to = to assign the outer variable",
+	"检查循环中用 += 拼接字符串、循环次数已知时向没有预分配容量的切片 append，以及 WriteString(fmt.Sprintf(...))": "check string concatenation with += in loops, appends to slices without preallocated capacity when the trip count is known, and WriteString(fmt.Sprintf(...))",
+	"循环中用 += 拼接字符串 %s，每次拼接都会分配新的字符串并复制已有的内容；改用 strings.Builder":                  "string %s is concatenated with += in a loop, and every concatenation allocates a new string and copies the existing content; use strings.Builder instead",
+	"改用 strings.Builder 拼接": "concatenate with strings.Builder",
+	"%s.%s 会先分配一个临时字符串，再复制到 %s 中；用 %s.%s(%s, ...) 直接写入": "%s.%s allocates a temporary string first and then copies it into %s; write directly with %s.%s(%s, ...)",
+	"改为 %s": "change to %s",
+	"循环会 append %s 次，%s 声明时没有指定容量，append 会多次扩容并复制已有的元素；声明时用 %s 预分配容量": "the loop appends %s times, but %s is declared without a capacity, so append grows it several times and copies the existing elements; preallocate the capacity with %s in the declaration",
+	"用 make 预分配容量": "preallocate the capacity with make",
+
+	// registry 和 README
+	"协程（Goroutines）陷阱":              "Goroutine traps",
+	"指针（Pointers）陷阱":                "Pointer traps",
+	"接口（Interfaces）陷阱":              "Interface traps",
+	"通道（Channels）陷阱":                "Channel traps",
+	"其他常见陷阱":                        "Other common traps",
+	"%s（%s）":                        "%s (%s)",
+	"=== %s（%s）":                    "=== %s (%s)",
+	"**问题**：%s。":                    "**Problem**: %s.",
+	"**受影响的 Go 版本**：%s":             "**Affected Go versions**: %s",
+	"**错误示例**：":                     "**Wrong way**:",
+	"**正确示例**：":                     "**Correct way**:",
+	"**示例**：":                       "**Example**:",
+	"**示例代码**：`%s`（运行：`go run %s`）": "**Example code**: `%s` (run: `go run %s`)",
+	"**基准测试**：错误写法和正确写法都有对应的基准测试，" +
+		"`go run ./cmd/gotrap bench %s` 会输出两者在 ns/op、B/op、allocs/op 上的比值，并检查是否达到预期的改进。": "**Benchmarks**: both the wrong and the correct way have benchmarks; " +
+		"`go run ./cmd/gotrap bench %s` prints their ratios in ns/op, B/op and allocs/op and checks that the expected improvement is reached.",
+
+	"闭包变量捕获问题": "Closure variable capture",
+	"在循环中使用 goroutine 时，所有 goroutine 可能共享同一个变量": "when goroutines are started in a loop, they may all share the same variable",
+	"**Go 1.22 的变化**：从 Go 1.22 开始（go.mod 中的 go 版本 >= 1.22，本仓库满足这个条件），" +
+		"`for` 循环的变量每次迭代都是新变量，`ClosureWrongWay` 中的 goroutine 会打印各自的 i，" +
+		"`ClosureCorrectWay2` 中的 `i := i` 也不再需要。" +
+		"但循环外声明的变量仍然被所有 goroutine 共享，`ClosureWrongWay2` 在任何版本下都有数据竞争。": "**Changed in Go 1.22**: since Go 1.22 (go version >= 1.22 in go.mod, which this repository satisfies), " +
+		"`for` loop variables are new variables on every iteration, so the goroutines in `ClosureWrongWay` print their own i " +
+		"and the `i := i` in `ClosureCorrectWay2` is no longer needed. " +
+		"A variable declared outside the loop is still shared by all goroutines, so `ClosureWrongWay2` has a data race in every version.",
+	"未等待 Goroutine 完成": "Not waiting for goroutines to finish",
+	"主程序在 goroutine 完成前退出，导致 goroutine 被强制终止": "the program exits before its goroutines finish, so they are killed",
+	"Goroutine 泄漏": "Goroutine leaks",
+	"goroutine 因为通道阻塞而永远无法退出，造成内存泄漏": "a goroutine blocked on a channel can never exit, leaking memory",
+	"WaitGroup 使用错误":                   "WaitGroup misuse",
+	"WaitGroup 使用不当导致死锁或 goroutine 泄漏": "misusing a WaitGroup causes deadlocks or goroutine leaks",
+	"Nil 指针解引用":                        "Nil pointer dereference",
+	"在使用指针前未检查是否为 nil，导致程序 panic":      "using a pointer without checking it for nil makes the program panic",
+	"返回局部变量指针":                         "Returning pointers to local variables",
+	"返回函数内部局部变量的指针，该变量在函数返回后可能被回收": "returning a pointer to a local variable that might be freed after the function returns",
+	"**注意**：在 Go 中，编译器会进行逃逸分析，通常会自动将变量分配到堆上，" +
+		"所以返回局部变量指针通常是安全的。但理解这个概念很重要。": "**Note**: the Go compiler performs escape analysis and moves such variables to the heap automatically, " +
+		"so returning a pointer to a local variable is usually safe. The concept is still worth understanding.",
+	"指针接收者 vs 值接收者":             "Pointer receivers vs value receivers",
+	"混淆指针接收者和值接收者的使用场景，导致意外的行为": "mixing up when to use pointer and value receivers leads to unexpected behavior",
+	"切片中的指针问题":                  "Pointers in slices",
+	"切片中存储指针时，容易产生意外的行为":        "storing pointers in slices easily leads to unexpected behavior",
+	"**Go 1.22 的变化**：从 Go 1.22 开始，`SlicePointerTrap1` 中每次迭代的 `&i` 指向不同的变量，" +
+		"会依次打印 0、1、2；只有 go.mod 中的 go 版本低于 1.22 时才会都打印 3。" +
+		"切片扩容导致的问题（`SlicePointerTrap2`）不受影响。": "**Changed in Go 1.22**: since Go 1.22, `&i` in `SlicePointerTrap1` points to a different variable on every iteration, " +
+		"so it prints 0, 1, 2; it prints 3 three times only when the go version in go.mod is below 1.22. " +
+		"The problem caused by slice growth (`SlicePointerTrap2`) is not affected.",
+	"Nil 接口值": "Nil interface values",
+	"接口值为 nil 但接口类型不为 nil，导致判断错误": "an interface holding a nil value has a non-nil type, so nil checks go wrong",
+	"接口类型断言": "Interface type assertions",
+	"类型断言失败时未检查 ok 值，导致 panic": "not checking ok when a type assertion fails causes a panic",
+	"空接口的使用": "Using the empty interface",
+	"过度使用空接口 interface{}，失去类型安全":                "overusing the empty interface interface{} gives up type safety",
+	"Interface 接收者问题":                           "Interface receivers",
+	"接口方法接收者的选择影响接口实现":                          "the receiver type of the methods decides which types implement an interface",
+	"未关闭通道导致泄漏":                                 "Leaks from unclosed channels",
+	"通道未正确关闭，导致接收方永远阻塞":                         "a channel that is never closed blocks its receivers forever",
+	"向已关闭通道发送数据":                                "Sending on a closed channel",
+	"向已关闭的通道发送数据会导致 panic":                      "sending on a closed channel panics",
+	"从已关闭通道读取":                                  "Receiving from a closed channel",
+	"从已关闭的通道读取会立即返回零值，需要检查通道状态":                 "receiving from a closed channel returns the zero value immediately, so the channel state must be checked",
+	"Select 的 Default Case":                     "The default case in select",
+	"select 语句中的 default case 可能导致非阻塞行为，影响程序逻辑": "a default case makes select non-blocking, which can break the program logic",
+	"切片和数组的区别":                                  "Slices vs arrays",
+	"混淆切片和数组，导致意外的行为":                           "mixing up slices and arrays leads to unexpected behavior",
+	"切片遍历时修改":                                   "Modifying a slice while ranging over it",
+	"在遍历切片时修改切片，导致意外的行为":                        "modifying a slice while iterating over it leads to unexpected behavior",
+	"Map 的并发读写":                                 "Concurrent map access",
+	"多个 goroutine 同时读写 map 会导致 panic":           "reading and writing a map from several goroutines at once crashes the program",
+	"**注意**：并发读写 map 触发的是运行时的 fatal error，不能被 recover。" +
+		"`go run ./cmd/gotrap race map_concurrent` 会用竞态检测器指出发生竞争的读写位置。": "**Note**: concurrent map access triggers a runtime fatal error, which cannot be recovered. " +
+		"`go run ./cmd/gotrap race map_concurrent` uses the race detector to point at the racing reads and writes.",
+	"nil map 写入": "Writing to a nil map",
+	"向 nil map 写入数据会导致 panic":  "writing to a nil map panics",
+	"Map 键类型限制":                "Map key type restrictions",
+	"map 的键类型必须是可比较的类型":        "map keys must be of a comparable type",
+	"Defer 的执行顺序":              "Defer execution order",
+	"defer 语句的执行顺序和参数求值时机容易混淆": "the execution order of defer statements and when their arguments are evaluated are easy to confuse",
+	"**注意**：defer 的执行顺序是 LIFO（后进先出），defer 可以修改命名返回值。": "**Note**: deferred calls run in LIFO (last in, first out) order, and a deferred function can modify named results.",
+	"错误处理": "Error handling",
+	"忽略错误或错误处理不当，导致程序行为异常":       "ignoring errors or handling them poorly makes the program misbehave",
+	"变量遮蔽（Variable Shadowing）":   "Variable shadowing",
+	"内部作用域的变量遮蔽外部作用域的变量，导致意外的行为": "a variable in an inner scope shadows one in an outer scope, leading to unexpected behavior",
+	"性能问题": "Performance pitfalls",
+	"常见的性能陷阱导致程序运行缓慢": "common performance traps make programs slow",
+}
@@ -0,0 +1,139 @@
+// Package i18n 是示例输出、gotrap 命令行和 README 的中英文消息目录。
+//
+// 与 gettext 一样，消息以中文原文为键：代码中直接写中文，
+// 选择英文时在 en.go 的目录中查找对应的译文，找不到时原样输出中文。
+// 查找前会去掉原文首尾的换行，译文再补上同样的换行，
+// 因此 "\n错误示例：" 和 "错误示例：" 共用一条译文。
+//
+// 语言由 -lang 参数或 LC_ALL、LC_MESSAGES、LANG 环境变量选择，默认是中文。
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Lang 是输出语言
+type Lang string
+
+const (
+	Zh Lang = "zh"
+	En Lang = "en"
+)
+
+// Langs 返回所有支持的语言，第一个是默认语言
+func Langs() []Lang {
+	return []Lang{Zh, En}
+}
+
+// Parse 解析语言名称，接受 "zh"、"en" 以及 "en_US.UTF-8" 这样的 locale 写法
+func Parse(s string) (Lang, error) {
+	name := strings.ToLower(s)
+	if i := strings.IndexAny(name, "_-.@"); i >= 0 {
+		name = name[:i]
+	}
+	switch name {
+	case "zh":
+		return Zh, nil
+	case "en":
+		return En, nil
+	}
+	return "", Errorf("不支持的语言 %q，可选 zh、en", s)
+}
+
+// FromEnv 按 POSIX 的优先级读取 LC_ALL、LC_MESSAGES、LANG，
+// 都没有设置或不是支持的语言（如 "C"）时返回中文
+func FromEnv() Lang {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(key)
+		if v == "" {
+			continue
+		}
+		if l, err := Parse(v); err == nil {
+			return l
+		}
+		return Zh
+	}
+	return Zh
+}
+
+var current atomic.Value // Lang
+
+func init() {
+	current.Store(FromEnv())
+}
+
+// Current 返回当前的输出语言
+func Current() Lang {
+	return current.Load().(Lang)
+}
+
+// SetLang 设置当前的输出语言
+func SetLang(l Lang) {
+	current.Store(l)
+}
+
+// Translate 返回中文原文 zh 在语言 l 中的译文
+func Translate(l Lang, zh string) string {
+	if l == Zh || zh == "" {
+		return zh
+	}
+	key := strings.Trim(zh, "\n")
+	tr, ok := catalogs[l][key]
+	if !ok {
+		return zh
+	}
+	start := strings.Index(zh, key)
+	return zh[:start] + tr + zh[start+len(key):]
+}
+
+var catalogs = map[Lang]map[string]string{
+	En: en,
+}
+
+// T 返回 zh 在当前语言中的译文
+func T(zh string) string {
+	return Translate(Current(), zh)
+}
+
+// Printf 与 fmt.Printf 相同，格式字符串按当前语言翻译
+func Printf(format string, args ...any) {
+	fmt.Printf(T(format), args...)
+}
+
+// Sprintf 与 fmt.Sprintf 相同，格式字符串按当前语言翻译
+func Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Println 与 fmt.Println 相同，字符串参数按当前语言翻译
+func Println(args ...any) {
+	for i, a := range args {
+		if s, ok := a.(string); ok {
+			args[i] = T(s)
+		}
+	}
+	fmt.Println(args...)
+}
+
+// Errorf 与 fmt.Errorf 相同，格式字符串按当前语言翻译
+func Errorf(format string, args ...any) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// Printer 按固定的语言翻译，用于同时生成多种语言的内容（如 README）
+type Printer struct {
+	Lang Lang
+}
+
+// T 返回 zh 在 p.Lang 中的译文
+func (p Printer) T(zh string) string {
+	return Translate(p.Lang, zh)
+}
+
+// Sprintf 与 fmt.Sprintf 相同，格式字符串翻译为 p.Lang
+func (p Printer) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(p.T(format), args...)
+}
@@ -0,0 +1,126 @@
+// Package race 解析 Go 竞态检测器（-race）输出的 "WARNING: DATA RACE" 报告。
+package race
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExitCode 是检测到数据竞争时进程的默认退出码（GORACE 的 exitcode 选项）
+const ExitCode = 66
+
+// Report 是一次数据竞争报告
+type Report struct {
+	Accesses   []Access    // 发生竞争的两次内存访问，第一项是当前访问，第二项是之前的访问
+	Goroutines []Goroutine // 参与竞争的 goroutine 及其创建位置
+}
+
+// Access 是一次内存访问
+type Access struct {
+	Op        string  // "read" 或 "write"
+	Previous  bool    // 是否是报告中 "Previous ..." 那次访问
+	Addr      string  // 访问的内存地址
+	Goroutine int     // 执行访问的 goroutine ID
+	Stack     []Frame // 访问时的调用栈，最内层在前
+}
+
+// Goroutine 描述参与竞争的 goroutine
+type Goroutine struct {
+	ID        int
+	State     string  // running、finished 等
+	CreatedAt []Frame // 创建该 goroutine 的调用栈
+}
+
+// Frame 是调用栈中的一帧
+type Frame struct {
+	Func string
+	File string
+	Line int
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("%s:%d", f.File, f.Line)
+}
+
+var (
+	accessRe    = regexp.MustCompile(`^(Previous )?(?i:(read|write)) at (0x[0-9a-f]+) by (?:goroutine (\d+)|main goroutine):$`)
+	goroutineRe = regexp.MustCompile(`^Goroutine (\d+) \((\w+)\) created at:$`)
+	locationRe  = regexp.MustCompile(`^\s+(.+):(\d+)(?: \+0x[0-9a-f]+)?$`)
+)
+
+// Parse 从 stderr 中解析出所有数据竞争报告
+func Parse(stderr []byte) []Report {
+	var reports []Report
+	var cur *Report
+	var stack *[]Frame // 当前正在追加的调用栈
+	var fn string      // 等待文件位置的函数名
+
+	sc := bufio.NewScanner(bytes.NewReader(stderr))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "WARNING: DATA RACE" {
+			reports = append(reports, Report{})
+			cur = &reports[len(reports)-1]
+			stack = nil
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if strings.HasPrefix(line, "==================") {
+			cur, stack = nil, nil
+			continue
+		}
+
+		if m := accessRe.FindStringSubmatch(line); m != nil {
+			id, _ := strconv.Atoi(m[4]) // main goroutine 的 ID 为 0
+			cur.Accesses = append(cur.Accesses, Access{
+				Op:        strings.ToLower(m[2]),
+				Previous:  m[1] != "",
+				Addr:      m[3],
+				Goroutine: id,
+			})
+			stack = &cur.Accesses[len(cur.Accesses)-1].Stack
+			continue
+		}
+		if m := goroutineRe.FindStringSubmatch(line); m != nil {
+			id, _ := strconv.Atoi(m[1])
+			cur.Goroutines = append(cur.Goroutines, Goroutine{ID: id, State: m[2]})
+			stack = &cur.Goroutines[len(cur.Goroutines)-1].CreatedAt
+			continue
+		}
+		if stack == nil {
+			continue
+		}
+		switch {
+		case line == "":
+			stack = nil
+		case strings.HasPrefix(line, "      "):
+			// 文件位置行，缩进比函数名更深
+			if m := locationRe.FindStringSubmatch(line); m != nil && fn != "" {
+				n, _ := strconv.Atoi(m[2])
+				*stack = append(*stack, Frame{Func: fn, File: m[1], Line: n})
+				fn = ""
+			}
+		case strings.HasPrefix(line, "  "):
+			fn = strings.TrimSuffix(strings.TrimSpace(line), "()")
+		}
+	}
+	return reports
+}
+
+// UserFrame 返回调用栈中第一个函数名以 prefix 开头的帧（如模块路径 "go-trap/"），
+// 用于在表格中展示竞争发生在示例代码的哪一行
+func UserFrame(stack []Frame, prefix string) (Frame, bool) {
+	for _, f := range stack {
+		if strings.HasPrefix(f.Func, prefix) {
+			return f, true
+		}
+	}
+	return Frame{}, false
+}
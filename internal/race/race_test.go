@@ -0,0 +1,146 @@
+package race
+
+import (
+	"reflect"
+	"testing"
+)
+
+const writeWrite = `==================
+WARNING: DATA RACE
+Write at 0x00c000014088 by goroutine 7:
+  main.main.func1()
+      /src/examples/cmd/race/main.go:12 +0x3c
+
+Previous write at 0x00c000014088 by main goroutine:
+  main.main()
+      /src/examples/cmd/race/main.go:15 +0x8c
+
+Goroutine 7 (running) created at:
+  main.main()
+      /src/examples/cmd/race/main.go:11 +0x7e
+==================
+`
+
+const readWrite = `==================
+WARNING: DATA RACE
+Read at 0x00c0000a0010 by goroutine 8:
+  go-trap/examples/goroutine.Counter.func1()
+      /src/examples/goroutine/counter.go:20 +0x44
+  sync.(*Once).Do()
+      /usr/local/go/src/sync/once.go:48
+
+Previous write at 0x00c0000a0010 by goroutine 9:
+  go-trap/examples/goroutine.Counter.func2()
+      /src/examples/goroutine/counter.go:25 +0x56
+
+Goroutine 8 (running) created at:
+  go-trap/examples/goroutine.Counter()
+      /src/examples/goroutine/counter.go:18 +0xa4
+
+Goroutine 9 (finished) created at:
+  go-trap/examples/goroutine.Counter()
+      /src/examples/goroutine/counter.go:23 +0x10c
+==================
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   []Report
+	}{
+		{
+			name:   "没有竞争",
+			stderr: "hello\nexit status 1\n",
+		},
+		{
+			name:   "main goroutine",
+			stderr: writeWrite + "Found 1 data race(s)\nexit status 66\n",
+			want: []Report{{
+				Accesses: []Access{
+					{Op: "write", Addr: "0x00c000014088", Goroutine: 7, Stack: []Frame{
+						{Func: "main.main.func1", File: "/src/examples/cmd/race/main.go", Line: 12},
+					}},
+					{Op: "write", Previous: true, Addr: "0x00c000014088", Stack: []Frame{
+						{Func: "main.main", File: "/src/examples/cmd/race/main.go", Line: 15},
+					}},
+				},
+				Goroutines: []Goroutine{
+					{ID: 7, State: "running", CreatedAt: []Frame{
+						{Func: "main.main", File: "/src/examples/cmd/race/main.go", Line: 11},
+					}},
+				},
+			}},
+		},
+		{
+			name:   "多份报告和多帧调用栈",
+			stderr: "start\n" + writeWrite + readWrite + "Found 2 data race(s)\n",
+			want: []Report{
+				{
+					Accesses: []Access{
+						{Op: "write", Addr: "0x00c000014088", Goroutine: 7, Stack: []Frame{
+							{Func: "main.main.func1", File: "/src/examples/cmd/race/main.go", Line: 12},
+						}},
+						{Op: "write", Previous: true, Addr: "0x00c000014088", Stack: []Frame{
+							{Func: "main.main", File: "/src/examples/cmd/race/main.go", Line: 15},
+						}},
+					},
+					Goroutines: []Goroutine{
+						{ID: 7, State: "running", CreatedAt: []Frame{
+							{Func: "main.main", File: "/src/examples/cmd/race/main.go", Line: 11},
+						}},
+					},
+				},
+				{
+					Accesses: []Access{
+						{Op: "read", Addr: "0x00c0000a0010", Goroutine: 8, Stack: []Frame{
+							{Func: "go-trap/examples/goroutine.Counter.func1", File: "/src/examples/goroutine/counter.go", Line: 20},
+							{Func: "sync.(*Once).Do", File: "/usr/local/go/src/sync/once.go", Line: 48},
+						}},
+						{Op: "write", Previous: true, Addr: "0x00c0000a0010", Goroutine: 9, Stack: []Frame{
+							{Func: "go-trap/examples/goroutine.Counter.func2", File: "/src/examples/goroutine/counter.go", Line: 25},
+						}},
+					},
+					Goroutines: []Goroutine{
+						{ID: 8, State: "running", CreatedAt: []Frame{
+							{Func: "go-trap/examples/goroutine.Counter", File: "/src/examples/goroutine/counter.go", Line: 18},
+						}},
+						{ID: 9, State: "finished", CreatedAt: []Frame{
+							{Func: "go-trap/examples/goroutine.Counter", File: "/src/examples/goroutine/counter.go", Line: 23},
+						}},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse([]byte(tt.stderr))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserFrame(t *testing.T) {
+	stack := []Frame{
+		{Func: "sync.(*Once).Do", File: "/usr/local/go/src/sync/once.go", Line: 48},
+		{Func: "go-trap/examples/goroutine.Counter", File: "/src/examples/goroutine/counter.go", Line: 18},
+	}
+	tests := []struct {
+		prefix string
+		want   Frame
+		ok     bool
+	}{
+		{"go-trap/", stack[1], true},
+		{"sync.", stack[0], true},
+		{"main.", Frame{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := UserFrame(stack, tt.prefix)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("UserFrame(%q) = %v, %v; want %v, %v", tt.prefix, got, ok, tt.want, tt.ok)
+		}
+	}
+}
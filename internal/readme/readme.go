@@ -0,0 +1,145 @@
+// Package readme 根据 registry 中的元数据和示例源码生成中文的 README.md 和英文的 README.en.md。
+//
+// 每个陷阱的章节标题、问题描述和补充说明来自 registry，英文版按 i18n 的消息目录翻译，
+// 错误示例和正确示例是示例文件中带 //readme:wrong、//readme:correct 标记的声明，
+// 用 go/ast 原样提取，因此 README 中的代码总是和可运行的示例一致。
+// 目录之前的介绍和“运行示例”等手写部分在 README.md.tmpl 和 README.en.md.tmpl 中。
+package readme
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"go-trap/internal/i18n"
+	"go-trap/registry"
+)
+
+//go:embed README.md.tmpl README.en.md.tmpl
+var tmplFS embed.FS
+
+var tmpl = template.Must(template.ParseFS(tmplFS, "*.tmpl"))
+
+// File 返回语言 lang 的 README 相对于仓库根目录的位置
+func File(lang i18n.Lang) string {
+	if lang == i18n.Zh {
+		return "README.md"
+	}
+	return "README." + string(lang) + ".md"
+}
+
+// DocURL 返回陷阱在语言 lang 的 README 中的链接
+func DocURL(t registry.Trap, lang i18n.Lang) string {
+	if lang == i18n.Zh {
+		return t.DocURL()
+	}
+	p := i18n.Printer{Lang: lang}
+	base := strings.TrimSuffix(registry.DocBase, File(i18n.Zh)) + File(lang)
+	for i, c := range registry.Categories() {
+		for j, u := range registry.ByCategory(c) {
+			if u.ID == t.ID {
+				return base + "#" + Anchor(fmt.Sprintf("%d.%d %s", i+1, j+1, p.T(t.Title)))
+			}
+		}
+	}
+	return base
+}
+
+// Generate 在仓库根目录 root 下读取示例源码，生成语言 lang 的 README 内容
+func Generate(root string, lang i18n.Lang) ([]byte, error) {
+	p := i18n.Printer{Lang: lang}
+	var toc, body strings.Builder
+	for i, c := range registry.Categories() {
+		heading := fmt.Sprintf("%d. %s", i+1, p.T(c.Title()))
+		fmt.Fprintf(&toc, "%d. [%s](#%s)\n", i+1, p.T(c.Title()), Anchor(heading))
+		fmt.Fprintf(&body, "## %s\n", heading)
+
+		for j, t := range registry.ByCategory(c) {
+			title := fmt.Sprintf("%d.%d %s", i+1, j+1, p.T(t.Title))
+			// registry 中记录的是中文 README 的锚点
+			if a := Anchor(title); lang == i18n.Zh && a != t.Anchor {
+				return nil, fmt.Errorf("%s: registry 中的锚点 %q 与章节标题生成的锚点 %q 不一致", t.ID, t.Anchor, a)
+			}
+			fmt.Fprintf(&toc, "   - %s\n", p.T(t.Title))
+
+			s, err := Extract(filepath.Join(root, t.Source))
+			if err != nil {
+				return nil, err
+			}
+			if err := section(&body, p, title, t, s); err != nil {
+				return nil, err
+			}
+		}
+		body.WriteString("\n---\n\n")
+	}
+
+	var out bytes.Buffer
+	err := tmpl.ExecuteTemplate(&out, File(lang)+".tmpl", struct{ TOC, Traps string }{
+		TOC:   strings.TrimSuffix(toc.String(), "\n"),
+		Traps: strings.TrimSuffix(body.String(), "\n---\n\n"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// section 用 p 的语言输出一个陷阱的章节，代码片段中的注释保持原样
+func section(b *strings.Builder, p i18n.Printer, title string, t registry.Trap, s Snippets) error {
+	if len(t.Wrong) > 0 && len(s.Wrong) == 0 {
+		return fmt.Errorf("%s: 没有带 %s 标记的声明", t.Source, markWrong)
+	}
+	if len(s.Correct) == 0 {
+		return fmt.Errorf("%s: 没有带 %s 标记的声明", t.Source, markCorrect)
+	}
+
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	b.WriteString(p.Sprintf("**问题**：%s。", p.T(t.Problem)) + "\n\n")
+	if t.GoVersions != registry.AllVersions {
+		b.WriteString(p.Sprintf("**受影响的 Go 版本**：%s", t.GoVersions) + "\n\n")
+	}
+	for _, n := range t.Notes {
+		fmt.Fprintf(b, "%s\n\n", p.T(n))
+	}
+
+	if len(s.Wrong) > 0 {
+		b.WriteString(p.T("**错误示例**：") + "\n")
+		code(b, s.Wrong)
+		b.WriteString("\n" + p.T("**正确示例**：") + "\n")
+	} else {
+		b.WriteString(p.T("**示例**：") + "\n")
+	}
+	code(b, s.Correct)
+
+	b.WriteString("\n" + p.Sprintf("**示例代码**：`%s`（运行：`go run %s`）", t.Source, t.Main()) + "\n")
+	if len(t.Benches) > 0 {
+		b.WriteString("\n" + p.Sprintf("**基准测试**：错误写法和正确写法都有对应的基准测试，"+
+			"`go run ./cmd/gotrap bench %s` 会输出两者在 ns/op、B/op、allocs/op 上的比值，并检查是否达到预期的改进。", t.ID) + "\n")
+	}
+	return nil
+}
+
+// code 输出一个 Go 代码块，多个片段之间空一行
+func code(b *strings.Builder, snippets []string) {
+	b.WriteString("```go\n")
+	b.WriteString(strings.Join(snippets, "\n\n"))
+	b.WriteString("\n```\n")
+}
+
+// Anchor 按 GitHub 的规则把标题转换为锚点：转为小写，去掉标点，空格换成连字符
+func Anchor(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package readme
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// 示例源码中的标记指令，写在函数或类型声明的文档注释末尾：
+//
+//	// 错误方式：直接解引用可能为 nil 的指针
+//	//
+//	//readme:wrong
+//	func NilWrongWay() {
+//
+// 带标记的声明（连同文档注释，去掉指令本身）按源码顺序放进 README 的错误示例或正确示例中。
+const (
+	markWrong   = "//readme:wrong"
+	markCorrect = "//readme:correct"
+)
+
+// Snippets 是从一个示例文件中提取出的代码片段
+type Snippets struct {
+	Wrong   []string
+	Correct []string
+}
+
+// Extract 解析示例源文件，返回所有带标记的声明
+func Extract(filename string) (Snippets, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return Snippets{}, err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return Snippets{}, err
+	}
+
+	var s Snippets
+	for _, decl := range f.Decls {
+		var doc *ast.CommentGroup
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			doc = d.Doc
+		case *ast.GenDecl:
+			doc = d.Doc
+		}
+		if doc == nil {
+			continue
+		}
+
+		wrong, correct := false, false
+		for _, c := range doc.List {
+			switch {
+			case c.Text == markWrong:
+				wrong = true
+			case c.Text == markCorrect:
+				correct = true
+			case strings.HasPrefix(c.Text, "//readme:"):
+				return Snippets{}, fmt.Errorf("%s: 未知的标记 %s", fset.Position(c.Pos()), c.Text)
+			}
+		}
+		if !wrong && !correct {
+			continue
+		}
+
+		start := fset.Position(doc.Pos()).Offset
+		end := fset.Position(decl.End()).Offset
+		code := clean(string(src[start:end]))
+		if wrong {
+			s.Wrong = append(s.Wrong, code)
+		}
+		if correct {
+			s.Correct = append(s.Correct, code)
+		}
+	}
+	return s, nil
+}
+
+// clean 去掉文档注释中的标记指令及其前面的空注释行，并把行首的 tab 换成 4 个空格
+func clean(code string) string {
+	lines := strings.Split(code, "\n")
+	var out []string
+	for _, l := range lines {
+		if strings.HasPrefix(l, "//readme:") {
+			// 去掉 gofmt 在指令前插入的空注释行
+			if n := len(out); n > 0 && out[n-1] == "//" {
+				out = out[:n-1]
+			}
+			continue
+		}
+		trimmed := strings.TrimLeft(l, "\t")
+		indent := len(l) - len(trimmed)
+		out = append(out, strings.Repeat("    ", indent)+trimmed)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,185 @@
+// Package report 把示例的运行结果输出为 JSON 或 JUnit XML，供仪表盘和测试报告工具直接读取。
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/runner"
+	"go-trap/registry"
+)
+
+// Entry 是一个示例的运行结果
+type Entry struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"` // 按当前语言翻译
+	Category   string     `json:"category"`
+	Passed     bool       `json:"passed"`
+	Status     string     `json:"status"` // passed、failed、timeout 或 build_error，不随语言变化
+	DurationMS int64      `json:"duration_ms"`
+	ExitCode   int        `json:"exit_code"` // 编译失败或超时时为 -1
+	TimedOut   bool       `json:"timed_out"`
+	BuildError string     `json:"build_error,omitempty"`
+	Stdout     string     `json:"stdout"`
+	Stderr     string     `json:"stderr"`
+	Panic      *PanicInfo `json:"panic,omitempty"`
+
+	duration time.Duration
+}
+
+// PanicInfo 是示例崩溃时的 panic 或 fatal error 信息
+type PanicInfo struct {
+	Kind    string `json:"kind"` // "panic" 或 "fatal"
+	Message string `json:"message"`
+	Trace   string `json:"trace"`
+}
+
+// NewEntry 根据陷阱元数据和运行结果生成一条记录
+func NewEntry(t registry.Trap, res *runner.Result) Entry {
+	e := Entry{
+		ID:         t.ID,
+		Title:      i18n.T(t.Title),
+		Category:   string(t.Category),
+		Passed:     res.Passed(),
+		Status:     res.Code(),
+		DurationMS: res.Duration.Milliseconds(),
+		ExitCode:   res.ExitCode,
+		TimedOut:   res.TimedOut,
+		Stdout:     string(res.Stdout),
+		Stderr:     string(res.Stderr),
+		duration:   res.Duration,
+	}
+	if res.BuildErr != nil {
+		e.BuildError = res.BuildErr.Error()
+	}
+	if f := res.Failure(); f != nil {
+		e.Panic = &PanicInfo{Kind: f.Kind, Message: f.Message, Trace: f.Trace}
+	}
+	return e
+}
+
+// WriteJSON 把所有记录输出为 JSON 数组，每个示例一个对象
+func WriteJSON(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out"`
+	SystemErr *junitOutput  `xml:"system-err"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",cdata"`
+}
+
+// junitOutput 用 CDATA 保存输出，避免换行被转义成 &#xA;
+type junitOutput struct {
+	Body string `xml:",cdata"`
+}
+
+func output(s string) *junitOutput {
+	if s == "" {
+		return nil
+	}
+	return &junitOutput{Body: s}
+}
+
+// WriteJUnit 把所有记录输出为 JUnit XML，每个分类一个 testsuite。
+// 编译失败记为 error，运行失败（非零退出、超时、崩溃）记为 failure。
+func WriteJUnit(w io.Writer, entries []Entry) error {
+	root := junitSuites{}
+	var total time.Duration
+	var durations []time.Duration // 每个 testsuite 的总耗时
+	index := make(map[string]int)
+	for _, e := range entries {
+		i, ok := index[e.Category]
+		if !ok {
+			i = len(root.Suites)
+			index[e.Category] = i
+			root.Suites = append(root.Suites, junitSuite{Name: "gotrap/" + e.Category})
+			durations = append(durations, 0)
+		}
+		s := &root.Suites[i]
+
+		c := junitCase{
+			Name:      e.ID,
+			Classname: "examples." + e.Category,
+			Time:      seconds(e.duration),
+			SystemOut: output(e.Stdout),
+			SystemErr: output(e.Stderr),
+		}
+		switch {
+		case e.BuildError != "":
+			c.Error = &junitMessage{Message: e.Status, Type: "build", Body: e.Stderr}
+			s.Errors++
+		case e.Panic != nil:
+			c.Failure = &junitMessage{Message: e.Panic.Kind + ": " + e.Panic.Message, Type: e.Panic.Kind, Body: e.Panic.Trace}
+			s.Failures++
+		case !e.Passed:
+			msg := e.Status
+			if !e.TimedOut {
+				msg = fmt.Sprintf("%s (exit code %d)", e.Status, e.ExitCode)
+			}
+			c.Failure = &junitMessage{Message: msg, Type: "exit", Body: e.Stderr}
+			s.Failures++
+		}
+		s.Cases = append(s.Cases, c)
+		s.Tests++
+		durations[i] += e.duration
+		total += e.duration
+	}
+	for i := range root.Suites {
+		s := &root.Suites[i]
+		s.Time = seconds(durations[i])
+		root.Tests += s.Tests
+		root.Failures += s.Failures
+		root.Errors += s.Errors
+	}
+	root.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
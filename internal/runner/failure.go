@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// Failure 是从示例 stderr 中解析出的崩溃信息
+type Failure struct {
+	Kind    string // "panic" 或 "fatal"
+	Message string // "panic: " 或 "fatal error: " 之后的第一行内容
+	Trace   string // 从崩溃信息开始的完整输出，包含 goroutine 栈
+}
+
+// Failure 解析示例的崩溃信息，示例没有崩溃时返回 nil
+func (r *Result) Failure() *Failure {
+	return ParseFailure(r.Stderr)
+}
+
+// ParseFailure 在 stderr 中查找 Go 运行时打印的 panic 或 fatal error。
+// 被 recover 的 panic 不会输出到 stderr，因此这里找到的都是导致进程退出的崩溃。
+func ParseFailure(stderr []byte) *Failure {
+	sc := bufio.NewScanner(bytes.NewReader(stderr))
+	sc.Buffer(make([]byte, 0, 64*1024), len(stderr)+1)
+	offset := 0
+	for sc.Scan() {
+		line := sc.Text()
+		var f *Failure
+		switch {
+		case strings.HasPrefix(line, "panic: "):
+			f = &Failure{Kind: "panic", Message: strings.TrimPrefix(line, "panic: ")}
+		case strings.HasPrefix(line, "fatal error: "):
+			f = &Failure{Kind: "fatal", Message: strings.TrimPrefix(line, "fatal error: ")}
+		}
+		if f != nil {
+			// panic 的值是 error 时，recover 后重新 panic 会带上 [recovered] 标记
+			f.Message = strings.TrimSuffix(f.Message, " [recovered]")
+			f.Trace = string(stderr[offset:])
+			return f
+		}
+		offset += len(line) + 1
+	}
+	return nil
+}
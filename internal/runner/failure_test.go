@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFailure(t *testing.T) {
+	const trace = "\ngoroutine 1 [running]:\nmain.main()\n\t/src/main.go:8 +0x1d\nexit status 2\n"
+	tests := []struct {
+		name   string
+		stderr string
+		want   *Failure
+	}{
+		{
+			name:   "没有崩溃",
+			stderr: "warning: something\nexit status 1\n",
+		},
+		{
+			name:   "panic",
+			stderr: "panic: runtime error: index out of range [3] with length 3" + trace,
+			want: &Failure{
+				Kind:    "panic",
+				Message: "runtime error: index out of range [3] with length 3",
+				Trace:   "panic: runtime error: index out of range [3] with length 3" + trace,
+			},
+		},
+		{
+			name:   "panic 之前的输出不在 Trace 中",
+			stderr: "log line\nanother line\npanic: boom" + trace,
+			want: &Failure{
+				Kind:    "panic",
+				Message: "boom",
+				Trace:   "panic: boom" + trace,
+			},
+		},
+		{
+			name:   "fatal error",
+			stderr: "fatal error: all goroutines are asleep - deadlock!\n" + trace,
+			want: &Failure{
+				Kind:    "fatal",
+				Message: "all goroutines are asleep - deadlock!",
+				Trace:   "fatal error: all goroutines are asleep - deadlock!\n" + trace,
+			},
+		},
+		{
+			name:   "重新 panic 时去掉 [recovered]",
+			stderr: "panic: assignment to entry in nil map [recovered]\n\tpanic: again" + trace,
+			want: &Failure{
+				Kind:    "panic",
+				Message: "assignment to entry in nil map",
+				Trace:   "panic: assignment to entry in nil map [recovered]\n\tpanic: again" + trace,
+			},
+		},
+		{
+			name:   "行首不是 panic 的不算",
+			stderr: "recovered: panic: boom\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseFailure([]byte(tt.stderr))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFailure() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultCode(t *testing.T) {
+	tests := []struct {
+		res  Result
+		want string
+	}{
+		{Result{}, StatusPassed},
+		{Result{ExitCode: 2}, StatusFailed},
+		{Result{ExitCode: -1, TimedOut: true}, StatusTimeout},
+		{Result{ExitCode: -1, BuildErr: errors.New("exit status 1")}, StatusBuildError},
+	}
+	for _, tt := range tests {
+		if got := tt.res.Code(); got != tt.want {
+			t.Errorf("%+v.Code() = %q, want %q", tt.res, got, tt.want)
+		}
+	}
+}
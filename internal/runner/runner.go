@@ -0,0 +1,189 @@
+// Package runner 负责编译并运行示例程序，收集每个示例的输出和退出状态。
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go-trap/internal/i18n"
+)
+
+// Job 描述一个待运行的示例
+type Job struct {
+	ID     string   // 示例 ID，用于标识结果
+	Target string   // 传给 go build 的目标：源文件或包路径
+	Args   []string // 运行时传给示例命令的参数
+}
+
+// Options 控制运行方式
+type Options struct {
+	Dir      string        // 执行 go build 的目录（模块根目录）
+	Parallel int           // 并发数，<= 1 表示依次运行
+	Timeout  time.Duration // 单个示例的运行超时，0 表示不限制
+	Race     bool          // 是否开启竞态检测器编译示例
+}
+
+// Result 记录一个示例的运行结果
+type Result struct {
+	ID       string
+	Duration time.Duration
+	ExitCode int // 进程退出码；编译失败或超时时为 -1
+	Stdout   []byte
+	Stderr   []byte
+	BuildErr error // 编译失败时非 nil，Stderr 中是编译输出
+	TimedOut bool
+}
+
+// Passed 报告示例是否编译成功并正常退出
+func (r *Result) Passed() bool {
+	return r.BuildErr == nil && !r.TimedOut && r.ExitCode == 0
+}
+
+// 运行结果的状态码，不随输出语言变化，写入 JSON 和 JUnit 报告
+const (
+	StatusPassed     = "passed"
+	StatusFailed     = "failed" // 非零退出
+	StatusTimeout    = "timeout"
+	StatusBuildError = "build_error"
+)
+
+// Code 返回运行结果的状态码（StatusPassed 等）
+func (r *Result) Code() string {
+	switch {
+	case r.BuildErr != nil:
+		return StatusBuildError
+	case r.TimedOut:
+		return StatusTimeout
+	case r.ExitCode != 0:
+		return StatusFailed
+	default:
+		return StatusPassed
+	}
+}
+
+// Status 返回按当前语言翻译的简短状态描述，用于控制台输出
+func (r *Result) Status() string {
+	switch {
+	case r.BuildErr != nil:
+		return i18n.T("编译失败")
+	case r.TimedOut:
+		return i18n.T("超时")
+	case r.ExitCode != 0:
+		return i18n.Sprintf("退出码 %d", r.ExitCode)
+	default:
+		return i18n.T("通过")
+	}
+}
+
+// Run 编译并运行所有 jobs，结果按 jobs 的顺序返回。
+// 同一个 Target 只编译一次，可以被多个 job 共用。
+func Run(ctx context.Context, jobs []Job, opts Options) ([]*Result, error) {
+	binDir, err := os.MkdirTemp("", "gotrap-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(binDir)
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	builds := make(map[string]*build)
+	for _, job := range jobs {
+		if builds[job.Target] == nil {
+			bin := filepath.Join(binDir, fmt.Sprintf("example%d", len(builds)))
+			builds[job.Target] = &build{target: job.Target, bin: bin}
+		}
+	}
+
+	results := make([]*Result, len(jobs))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, job Job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = runOne(ctx, job, builds[job.Target], opts)
+		}(i, job)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// build 记录一个 Target 的编译结果，多个 job 共用
+type build struct {
+	target string
+	bin    string
+	once   sync.Once
+	out    []byte
+	err    error
+}
+
+func (b *build) do(ctx context.Context, opts Options) error {
+	b.once.Do(func() {
+		args := []string{"build", "-o", b.bin}
+		if opts.Race {
+			args = append(args, "-race")
+		}
+		cmd := exec.CommandContext(ctx, "go", append(args, b.target)...)
+		cmd.Dir = opts.Dir
+		b.out, b.err = cmd.CombinedOutput()
+	})
+	return b.err
+}
+
+// runOne 编译单个示例并在超时限制内运行它
+func runOne(ctx context.Context, job Job, b *build, opts Options) *Result {
+	res := &Result{ID: job.ID, ExitCode: -1}
+	start := time.Now()
+	defer func() { res.Duration = time.Since(start) }()
+
+	if err := b.do(ctx, opts); err != nil {
+		res.BuildErr = err
+		res.Stderr = b.out
+		return res
+	}
+
+	runCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, b.bin, job.Args...)
+	cmd.Dir = opts.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// 示例中泄漏的 goroutine 不会阻止进程退出，但要防止子进程继承的管道迟迟不关闭
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	res.Stdout = stdout.Bytes()
+	res.Stderr = stderr.Bytes()
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		res.TimedOut = true
+		return res
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		res.ExitCode = 0
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	default:
+		res.Stderr = append(res.Stderr, err.Error()...)
+	}
+	return res
+}
@@ -0,0 +1,25 @@
+package trapvet
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var channelClose = newAnalyzer("channel_close",
+	"检查有接收方等待关闭、却从来没有被关闭的局部通道",
+	runChannelClose)
+
+func runChannelClose(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		for v, use := range localChans(pass, decl.Body) {
+			if use.escapes || len(use.closes) > 0 || use.waitClose == nil {
+				continue
+			}
+			report(pass, "channel_close", use.make, nil,
+				"通道 %s 从来没有被关闭，用 range 或 v, ok := <-%s 等待关闭的接收方会在数据发送完后永远阻塞；由发送方在发送完成后调用 close(%s)，通常写成 defer close(%s)",
+				v.Name(), v.Name(), v.Name(), v.Name())
+		}
+	})
+	return nil, nil
+}
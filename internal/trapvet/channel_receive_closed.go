@@ -0,0 +1,30 @@
+package trapvet
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var channelReceiveClosed = newAnalyzer("channel_receive_closed",
+	"检查在无条件的 for 循环中用单值接收读取会被关闭的通道",
+	runChannelReceiveClosed)
+
+func runChannelReceiveClosed(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		for v, use := range localChans(pass, decl.Body) {
+			if len(use.closes) == 0 {
+				continue
+			}
+			for _, r := range use.recvs {
+				if !r.forever {
+					continue
+				}
+				report(pass, "channel_receive_closed", r.expr, nil,
+					"通道 %s 会被关闭，关闭后 <-%s 立即返回零值，循环无法区分零值和关闭；用 v, ok := <-%s 检查 ok，或改用 for v := range %s",
+					v.Name(), v.Name(), v.Name(), v.Name())
+			}
+		}
+	})
+	return nil, nil
+}
@@ -0,0 +1,122 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var channelSelectDefault = newAnalyzer("channel_select_default",
+	"检查 for 循环中带有空的或只有一条简单语句的 default 分支的 select：循环中没有其他阻塞操作时，没有分支就绪就立即进入下一次迭代，空转占满一个 CPU 核心",
+	runChannelSelectDefault)
+
+func runChannelSelectDefault(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if loop, ok := n.(*ast.ForStmt); ok {
+				checkBusyLoop(pass, loop)
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// checkBusyLoop 报告循环体中直接带有 default 分支的 select：default 分支为空或只有一条简单语句，
+// 循环体中 select 之外也没有通道操作或函数调用等可能阻塞的操作
+func checkBusyLoop(pass *analysis.Pass, loop *ast.ForStmt) {
+	var sel *ast.SelectStmt
+	var def *ast.CommClause
+	for _, stmt := range loop.Body.List {
+		s, ok := stmt.(*ast.SelectStmt)
+		if !ok {
+			if mayBlock(pass, stmt) {
+				return
+			}
+			continue
+		}
+		for _, c := range s.Body.List {
+			if cc := c.(*ast.CommClause); cc.Comm == nil && sel == nil {
+				sel, def = s, cc
+			}
+		}
+		if sel != s && mayBlock(pass, s) {
+			return // 没有 default 的 select 会阻塞
+		}
+	}
+	if def == nil || len(def.Body) > 1 || len(def.Body) == 1 && !trivialStmt(pass, def.Body[0]) {
+		return
+	}
+	if len(def.Body) == 0 {
+		report(pass, "channel_select_default", def, busyLoopFix(pass, loop, sel, def),
+			"for 循环中的 select 带有空的 default 分支，其他分支都没有就绪时循环立即进入下一次迭代，空转占满一个 CPU 核心；去掉 default 让 select 阻塞等待，等待期间需要做其他事情时加一个 time.Ticker 分支（正确方式4）")
+	} else {
+		report(pass, "channel_select_default", def, nil,
+			"for 循环中的 select 的 default 分支只有一条简单语句，其他分支都没有就绪时循环立即进入下一次迭代，空转占满一个 CPU 核心；去掉 default 让 select 阻塞等待，等待期间需要做其他事情时加一个 time.Ticker 分支（正确方式4）")
+	}
+}
+
+// trivialStmt 报告 stmt 是否是不会让出 CPU 的简单语句：continue、自增自减，或不调用函数的赋值
+func trivialStmt(pass *analysis.Pass, stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.BranchStmt:
+		return s.Tok == token.CONTINUE && s.Label == nil
+	case *ast.IncDecStmt:
+		return !mayBlock(pass, s)
+	case *ast.AssignStmt:
+		return !mayBlock(pass, s)
+	}
+	return false
+}
+
+// mayBlock 报告 node 中是否有可能阻塞的操作：通道的发送、接收和 range，select，以及除内置函数和类型转换之外的函数调用
+func mayBlock(pass *analysis.Pass, node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SendStmt, *ast.SelectStmt:
+			found = true
+		case *ast.UnaryExpr:
+			found = found || n.Op == token.ARROW
+		case *ast.RangeStmt:
+			_, isChan := pass.TypesInfo.TypeOf(n.X).Underlying().(*types.Chan)
+			found = found || isChan
+		case *ast.CallExpr:
+			tv := pass.TypesInfo.Types[n.Fun]
+			found = found || !tv.IsType() && !tv.IsBuiltin()
+		}
+		return !found
+	})
+	return found
+}
+
+// busyLoopFix 返回删除 default 分支的建议修复。循环有条件（select 阻塞后条件不会再被检查）、
+// select 只有 default 分支或 default 分支和其他代码在同一行时返回 nil
+func busyLoopFix(pass *analysis.Pass, loop *ast.ForStmt, sel *ast.SelectStmt, def *ast.CommClause) []analysis.SuggestedFix {
+	if loop.Cond != nil || len(sel.Body.List) < 2 {
+		return nil
+	}
+	tf := pass.Fset.File(def.Pos())
+	end := sel.Body.Rbrace
+	for i, c := range sel.Body.List {
+		if c == def && i+1 < len(sel.Body.List) {
+			end = sel.Body.List[i+1].Pos()
+		}
+	}
+	if !startsLine(pass, def.Pos()) || !startsLine(pass, end) {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: i18n.T("删除 default 分支"),
+		TextEdits: []analysis.TextEdit{{
+			Pos: tf.LineStart(tf.Line(def.Pos())),
+			End: tf.LineStart(tf.Line(end)),
+		}},
+	}}
+}
@@ -0,0 +1,315 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/cfg"
+)
+
+var channelSendClosed = newAnalyzer("channel_send_closed",
+	"检查可能发生在 close 之后的发送和 close，以及由接收方关闭通道",
+	runChannelSendClosed)
+
+// channel_send_closed 的子陷阱
+const (
+	chanSendAfterClose = "channel_send_closed/send_after_close" // 发送可能发生在 close 之后
+	chanDoubleClose    = "channel_send_closed/double_close"     // close 可能发生在另一次 close 之后
+	chanReceiverClose  = "channel_send_closed/receiver_close"   // 接收方关闭了发送方还在使用的通道
+)
+
+// chanOp 是对通道的一种操作
+type chanOp int
+
+const (
+	opWait chanOp = iota // 等待其他 goroutine：Wait 调用或从其他通道接收
+	opSend
+	opRecv
+	opClose
+)
+
+// chanEvent 是对通道的一次操作
+type chanEvent struct {
+	node     ast.Node
+	op       chanOp
+	g        *ast.GoStmt // 所在的 goroutine，nil 表示函数本身
+	deferred bool        // 在 defer 中执行
+	once     bool        // 在 sync.Once.Do 中执行，最多执行一次
+}
+
+func runChannelSendClosed(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		checkReceiverCloseParams(pass, decl)
+		for v, use := range localChans(pass, decl.Body) {
+			if use.escapes || len(use.closes) == 0 {
+				continue
+			}
+			events, waits := chanEvents(pass, decl.Body, v)
+			flows := &flowGraphs{pass: pass, body: decl.Body}
+			checkAfterClose(pass, flows, v, events, waits)
+			checkReceiverClose(pass, flows, v, events, waits)
+		}
+	})
+	return nil, nil
+}
+
+// chanEvents 按出现顺序收集函数体中对通道 v 的发送、接收和关闭，以及各处等待其他 goroutine 的位置
+func chanEvents(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var) ([]chanEvent, []chanEvent) {
+	var events, waits []chanEvent
+	inspectStack(body, func(n ast.Node, stack []ast.Node) bool {
+		ev := chanEvent{node: n, op: opWait}
+		switch n := n.(type) {
+		case *ast.SendStmt:
+			if localVar(pass, n.Chan) != v {
+				return true
+			}
+			ev.op = opSend
+		case *ast.CallExpr:
+			if isBuiltin(pass, n, "close") && len(n.Args) == 1 && localVar(pass, n.Args[0]) == v {
+				ev.op = opClose
+			} else if !isWaitPoint(n) {
+				return true
+			}
+		case *ast.UnaryExpr:
+			if n.Op != token.ARROW {
+				return true
+			}
+			if localVar(pass, n.X) == v {
+				ev.op = opRecv
+			}
+		case *ast.RangeStmt:
+			if localVar(pass, n.X) != v {
+				return true
+			}
+			ev.op = opRecv
+		default:
+			return true
+		}
+		ev.g = innermostGoroutine(stack)
+		if ev.op == opWait {
+			waits = append(waits, ev)
+			return true
+		}
+		for i := len(stack) - 1; i >= 0 && stack[i] != ev.g; i-- {
+			switch s := stack[i].(type) {
+			case *ast.DeferStmt:
+				ev.deferred = true
+			case *ast.CallExpr:
+				if isMethodCall(pass, s, "sync", "Once", "Do") {
+					ev.once = true
+				}
+			}
+		}
+		events = append(events, ev)
+		return true
+	})
+	return events, waits
+}
+
+// innermostGoroutine 返回 stack 中最内层的 go 语句启动的函数字面量对应的 go 语句，不在 goroutine 中时返回 nil
+func innermostGoroutine(stack []ast.Node) *ast.GoStmt {
+	for i := len(stack) - 1; i >= 2; i-- {
+		if lit, ok := stack[i].(*ast.FuncLit); ok && goroutineLit(lit, stack[i-1], stack[i-2]) {
+			return stack[i-2].(*ast.GoStmt)
+		}
+	}
+	return nil
+}
+
+// checkAfterClose 报告可能发生在某次 close 之后的发送和 close
+func checkAfterClose(pass *analysis.Pass, flows *flowGraphs, v *types.Var, events, waits []chanEvent) {
+	for _, e := range events {
+		if e.op == opRecv || e.op == opClose && e.once {
+			continue
+		}
+		for _, x := range events {
+			if x.op != opClose || x.node == e.node || x.once || !mayFollowClose(flows, x, e, waits) {
+				continue
+			}
+			if e.op == opSend {
+				report(pass, chanSendAfterClose, e.node, nil,
+					"向通道 %s 发送可能发生在 close(%s) 之后，会 panic: send on closed channel；只让发送方在所有发送完成后关闭通道",
+					v.Name(), v.Name())
+			} else {
+				report(pass, chanDoubleClose, e.node, nil,
+					"通道 %s 可能已经被关闭，再次 close 会 panic: close of closed channel；只在一个地方关闭通道，或用 sync.Once 保证只关闭一次",
+					v.Name())
+			}
+			break
+		}
+	}
+}
+
+// mayFollowClose 报告发送或关闭 e 是否可能发生在 close x 之后
+func mayFollowClose(flows *flowGraphs, x, e chanEvent, waits []chanEvent) bool {
+	switch {
+	case x.g == e.g:
+		// 同一个 goroutine 中沿控制流判断先后，defer 在函数返回时按相反的顺序执行
+		if x.deferred {
+			return e.deferred && e.node.Pos() < x.node.Pos()
+		}
+		return e.deferred || flows.reaches(x.g, x.node, e.node)
+	case x.g == nil:
+		// 函数本身关闭通道：之后启动的 goroutine 一定在 close 之后；
+		// 之前启动的 goroutine 只有在 close 之前等待过（如 wg.Wait()）才认为已经结束
+		if !x.deferred && e.g.Pos() > x.node.Pos() {
+			return true
+		}
+		return !waitsBetween(waits, nil, e.g.Pos(), closePos(x))
+	case e.g == nil:
+		// goroutine 中关闭通道，函数本身在启动它之后的发送可能发生在 close 之后
+		return e.deferred || e.node.Pos() > x.g.Pos()
+	default:
+		// 两个不同的 goroutine：关闭方在 close 之前等待过时认为发送方已经结束
+		return !waitsBetween(waits, x.g, x.g.Pos(), x.node.Pos())
+	}
+}
+
+// flowGraphs 按需构建函数本身和其中各个 goroutine 的控制流图
+type flowGraphs struct {
+	pass   *analysis.Pass
+	body   *ast.BlockStmt
+	graphs map[*ast.GoStmt]*cfg.CFG
+}
+
+// reaches 报告在 goroutine g（nil 表示函数本身）中，from 执行之后是否还可能执行 to：
+// 沿控制流从 from 出发能到达 to，经过 return 或 panic 的路径不算，if 的两个分支互不可达
+func (f *flowGraphs) reaches(g *ast.GoStmt, from, to ast.Node) bool {
+	if f.graphs == nil {
+		f.graphs = make(map[*ast.GoStmt]*cfg.CFG)
+	}
+	graph := f.graphs[g]
+	if graph == nil {
+		body := f.body
+		if g != nil {
+			body = g.Call.Fun.(*ast.FuncLit).Body
+		}
+		graph = cfg.New(body, func(call *ast.CallExpr) bool {
+			return !isBuiltin(f.pass, call, "panic")
+		})
+		f.graphs[g] = graph
+	}
+	fromBlock, fromIndex := blockOf(graph, from)
+	toBlock, toIndex := blockOf(graph, to)
+	if fromBlock == nil || toBlock == nil || fromBlock == toBlock && fromIndex == toIndex {
+		// 在同一条语句中（比如同一个函数字面量中），或不在控制流图中，按源代码顺序判断
+		return to.Pos() > from.Pos()
+	}
+	if fromBlock == toBlock && toIndex > fromIndex {
+		return true
+	}
+	seen := make(map[*cfg.Block]bool)
+	queue := append([]*cfg.Block(nil), fromBlock.Succs...)
+	for len(queue) > 0 {
+		b := queue[0]
+		queue = queue[1:]
+		if b == toBlock {
+			return true
+		}
+		if !seen[b] {
+			seen[b] = true
+			queue = append(queue, b.Succs...)
+		}
+	}
+	return false
+}
+
+// blockOf 返回控制流图中包含 n 的最小节点所在的基本块和它在块中的位置。
+// range 语句本身也是循环头中的节点，所以要取最小的
+func blockOf(graph *cfg.CFG, n ast.Node) (*cfg.Block, int) {
+	var block *cfg.Block
+	index := -1
+	var size token.Pos
+	for _, b := range graph.Blocks {
+		for i, node := range b.Nodes {
+			if node.Pos() <= n.Pos() && n.End() <= node.End() && (block == nil || node.End()-node.Pos() < size) {
+				block, index, size = b, i, node.End()-node.Pos()
+			}
+		}
+	}
+	return block, index
+}
+
+// closePos 返回 close 执行的位置，defer 中的 close 在函数结束时执行
+func closePos(x chanEvent) token.Pos {
+	if x.deferred {
+		return token.Pos(1<<31 - 1)
+	}
+	return x.node.Pos()
+}
+
+// waitsBetween 报告 goroutine g（nil 表示函数本身）在 from 和 to 之间是否有等待
+func waitsBetween(waits []chanEvent, g *ast.GoStmt, from, to token.Pos) bool {
+	for _, w := range waits {
+		if w.g == g && from < w.node.Pos() && w.node.Pos() < to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReceiverClose 报告只接收通道的 goroutine 关闭了其他 goroutine 在 close 之后仍可能发送的通道
+func checkReceiverClose(pass *analysis.Pass, flows *flowGraphs, v *types.Var, events, waits []chanEvent) {
+	sends := make(map[*ast.GoStmt]bool)
+	recvs := make(map[*ast.GoStmt]bool)
+	for _, e := range events {
+		switch e.op {
+		case opSend:
+			sends[e.g] = true
+		case opRecv:
+			recvs[e.g] = true
+		}
+	}
+	for _, x := range events {
+		if x.op != opClose || !recvs[x.g] || sends[x.g] {
+			continue
+		}
+		for _, e := range events {
+			if e.op == opSend && e.g != x.g && mayFollowClose(flows, x, e, waits) {
+				report(pass, chanReceiverClose, x.node, nil,
+					"接收方关闭了通道 %s，而其他 goroutine 还在向它发送，阻塞中或之后的发送会 panic: send on closed channel；应该由发送方关闭通道，接收方用单独的 done 通道或 context 通知发送方停止",
+					v.Name())
+				break
+			}
+		}
+	}
+}
+
+// checkReceiverCloseParams 报告函数只从通道参数接收却关闭了它：调用方通常还持有发送端
+func checkReceiverCloseParams(pass *analysis.Pass, decl *ast.FuncDecl) {
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+	sig := fn.Type().(*types.Signature)
+	for i := 0; i < sig.Params().Len(); i++ {
+		p := sig.Params().At(i)
+		ch, ok := p.Type().Underlying().(*types.Chan)
+		if !ok || ch.Dir() != types.SendRecv {
+			continue
+		}
+		events, _ := chanEvents(pass, decl.Body, p)
+		var closes []ast.Node
+		sends, recvs := false, false
+		for _, e := range events {
+			switch e.op {
+			case opSend:
+				sends = true
+			case opRecv:
+				recvs = true
+			case opClose:
+				closes = append(closes, e.node)
+			}
+		}
+		if !recvs || sends {
+			continue
+		}
+		for _, c := range closes {
+			report(pass, chanReceiverClose, c, nil,
+				"%s 只从通道参数 %s 接收却关闭了它，调用方的发送会 panic: send on closed channel；应该由发送方关闭通道，参数可以声明为 <-chan 防止误关",
+				fn.Name(), p.Name())
+		}
+	}
+}
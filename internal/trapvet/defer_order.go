@@ -0,0 +1,106 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var deferOrder = newAnalyzer("defer_order",
+	"检查 defer 调用的参数在 defer 之后被修改，以及 defer 中修改了非命名返回值的局部变量",
+	runDeferOrder)
+
+func runDeferOrder(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		sig, _ := pass.TypesInfo.Defs[decl.Name].Type().(*types.Signature)
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				// 函数字面量中的 defer 属于它自己，简单起见不检查
+				return false
+			}
+			d, ok := n.(*ast.DeferStmt)
+			if !ok {
+				return true
+			}
+			if lit, ok := d.Call.Fun.(*ast.FuncLit); ok {
+				checkDeferredResult(pass, decl, sig, lit)
+				return true
+			}
+			checkDeferredArgs(pass, decl, d)
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// checkDeferredArgs 报告 defer 调用的参数中在 defer 之后才被修改的局部变量：
+// 参数在执行 defer 语句时就已求值，被调用时看不到之后的修改
+func checkDeferredArgs(pass *analysis.Pass, decl *ast.FuncDecl, d *ast.DeferStmt) {
+	for _, arg := range d.Call.Args {
+		var found *ast.Ident
+		ast.Inspect(arg, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false
+			}
+			id, ok := n.(*ast.Ident)
+			if !ok || found != nil {
+				return found == nil
+			}
+			if v := localVar(pass, id); v != nil && assignedAfter(pass, decl.Body, v, d) {
+				found = id
+			}
+			return true
+		})
+		if found != nil {
+			report(pass, "defer_order", found, nil,
+				"defer 调用的参数 %s 在执行 defer 语句时就已求值，之后对 %s 的修改不会反映到延迟调用中；需要最新的值时改为 defer func() { ... }() 在闭包中引用 %s",
+				found.Name, found.Name, found.Name)
+			return
+		}
+	}
+}
+
+// assignedAfter 报告 v 是否在 node 之后被赋值
+func assignedAfter(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var, node ast.Node) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil || found {
+			return false
+		}
+		if n.End() <= node.End() {
+			// 整个节点都在 node 之前，只有包含 node 的节点才需要继续查看
+			return n.Pos() <= node.Pos() && node.End() <= n.End()
+		}
+		if n.Pos() >= node.End() && assigns(pass, n, v) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// checkDeferredResult 报告 defer 的函数字面量中修改、随后又被 return 返回的局部变量：
+// 结果不是命名返回值时，return 已经把值复制到结果中，defer 中的修改不影响返回值
+func checkDeferredResult(pass *analysis.Pass, decl *ast.FuncDecl, sig *types.Signature, lit *ast.FuncLit) {
+	if sig == nil || sig.Results().Len() == 0 || sig.Results().At(0).Name() != "" {
+		return
+	}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || ret.Pos() < lit.End() {
+			return true
+		}
+		for _, r := range ret.Results {
+			if v := localVar(pass, r); v != nil && assigns(pass, lit.Body, v) {
+				report(pass, "defer_order", r, nil,
+					"%s 在 defer 中被修改，但函数的返回值不是命名返回值，return %s 时已经复制了结果，defer 中的修改不会影响返回值；需要在 defer 中修改返回值时使用命名返回值",
+					v.Name(), v.Name())
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,516 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var errorHandling = newAnalyzer("error_handling",
+	"检查在检查错误之前 defer 调用返回值的方法，循环中一直累积到函数返回才执行的 defer，用 == 比较可能被包装或每次新建的错误，以及用 %v、%s 而不是 %w 包装错误",
+	runErrorHandling)
+
+// error_handling 的子陷阱
+const (
+	errDeferBeforeCheck = "error_handling/defer_before_check" // 检查错误之前 defer 调用返回值的方法
+	errDeferInLoop      = "error_handling/defer_in_loop"      // 循环中的 defer
+	errEqualCompare     = "error_handling/equal_compare"      // 用 == 比较可能被包装或每次新建的错误
+	errWrapVerb         = "error_handling/wrap_verb"          // 用 %v 或 %s 而不是 %w 包装错误
+)
+
+func runErrorHandling(pass *analysis.Pass) (any, error) {
+	wrapping := wrappingFuncs(pass)
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+			var list []ast.Stmt
+			switch n := n.(type) {
+			case *ast.DeferStmt:
+				checkDeferInLoop(pass, n, stack)
+			case *ast.BinaryExpr:
+				checkErrorCompare(pass, decl.Body, n, wrapping)
+			case *ast.CallExpr:
+				checkWrapVerb(pass, n)
+			case *ast.BlockStmt:
+				list = n.List
+			case *ast.CaseClause:
+				list = n.Body
+			case *ast.CommClause:
+				list = n.Body
+			}
+			for i, stmt := range list {
+				checkDeferBeforeCheck(pass, stmt, list[i+1:])
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// checkDeferBeforeCheck 检查 stmt 中 x, err := f() 形式的调用：err 被检查之前（或被 _ 忽略时）
+// 出现的 defer x.M() 在 f 出错时会在 nil 或无效的 x 上调用
+func checkDeferBeforeCheck(pass *analysis.Pass, stmt ast.Stmt, rest []ast.Stmt) {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(as.Rhs) != 1 || len(as.Lhs) < 2 {
+		return
+	}
+	call, ok := ast.Unparen(as.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	results, ok := pass.TypesInfo.TypeOf(call).(*types.Tuple)
+	if !ok || results.Len() != len(as.Lhs) || !types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type()) {
+		return
+	}
+	var errVar *types.Var // 错误被 _ 忽略时为 nil
+	if last := identOf(as.Lhs[len(as.Lhs)-1]); last != nil && last.Name != "_" {
+		errVar = localVar(pass, last)
+	}
+	for _, lhs := range as.Lhs[:len(as.Lhs)-1] {
+		x := localVar(pass, lhs)
+		if x == nil {
+			continue
+		}
+		switch x.Type().Underlying().(type) {
+		case *types.Pointer, *types.Interface:
+		default:
+			continue
+		}
+		for j, next := range rest {
+			if d, ok := next.(*ast.DeferStmt); ok && deferredMethodOf(pass, d) == x {
+				sel := d.Call.Fun.(*ast.SelectorExpr)
+				if errVar == nil {
+					report(pass, errDeferBeforeCheck, d, nil,
+						"%s 返回的错误被忽略了，出错时 %s 可能是 nil，defer %s.%s() 会在 nil 上调用；先检查错误，确认没有出错后再 defer",
+						types.ExprString(call.Fun), x.Name(), x.Name(), sel.Sel.Name)
+				} else {
+					report(pass, errDeferBeforeCheck, d, moveDeferFix(pass, d, rest[j+1:], errVar),
+						"defer %s.%s() 在检查 %s 之前，%s 出错时 %s 可能是 nil，延迟调用会在 nil 上执行；把 defer 移到 if %s != nil 检查之后",
+						x.Name(), sel.Sel.Name, errVar.Name(), types.ExprString(call.Fun), x.Name(), errVar.Name())
+				}
+				break
+			}
+			if errVar != nil && refersTo(pass, next, errVar) || guardsNil(pass, next, x) || assigns(pass, next, x) {
+				break
+			}
+		}
+	}
+}
+
+// deferredMethodOf 返回 defer x.M(...) 中的局部变量 x，不是这种形式时返回 nil
+func deferredMethodOf(pass *analysis.Pass, d *ast.DeferStmt) *types.Var {
+	sel, ok := ast.Unparen(d.Call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if s, ok := pass.TypesInfo.Selections[sel]; !ok || s.Kind() != types.MethodVal {
+		return nil
+	}
+	return localVar(pass, sel.X)
+}
+
+// moveDeferFix 返回把 defer 语句 d 移到紧随其后的 if err != nil { ... return } 之后的建议修复，
+// 后面不是这样的检查时返回 nil
+func moveDeferFix(pass *analysis.Pass, d *ast.DeferStmt, rest []ast.Stmt, errVar *types.Var) []analysis.SuggestedFix {
+	if len(rest) == 0 {
+		return nil
+	}
+	check, ok := rest[0].(*ast.IfStmt)
+	if !ok || check.Init != nil || check.Else != nil || !refersTo(pass, check.Cond, errVar) || !terminates(check.Body) {
+		return nil
+	}
+	tf := pass.Fset.File(d.Pos())
+	line := tf.Line(d.Pos())
+	if line >= tf.LineCount() || tf.Line(d.End()) != line || tf.Line(check.Pos()) != line+1 {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: i18n.T("把 defer 移到错误检查之后"),
+		TextEdits: []analysis.TextEdit{
+			{Pos: tf.LineStart(line), End: tf.LineStart(line + 1)},
+			afterLine(pass, check.End(), lineIndent(pass, d.Pos())+sourceText(pass, d)),
+		},
+	}}
+}
+
+// checkDeferInLoop 报告循环中的 defer：延迟调用要到函数返回时才执行，每次迭代打开的资源会一直累积。
+// stack 是 d 的父节点路径，只看同一个函数中的循环
+func checkDeferInLoop(pass *analysis.Pass, d *ast.DeferStmt, stack []ast.Node) {
+	var loop ast.Node
+	for i := len(stack) - 1; i >= 0 && loop == nil; i-- {
+		if _, ok := stack[i].(*ast.FuncLit); ok {
+			return
+		}
+		if isLoop(stack[i]) {
+			loop = stack[i]
+		}
+	}
+	if loop == nil {
+		return
+	}
+	report(pass, errDeferInLoop, d, loopClosureFix(pass, loopBody(loop)),
+		"defer 要到函数返回时才执行，循环中的 defer %s 会一直累积，每次迭代的资源在整个循环结束之前都不会释放；把循环体放进函数字面量中，让 defer 在每次迭代结束时执行",
+		types.ExprString(d.Call.Fun))
+}
+
+// loopClosureFix 返回把循环体放进立即调用的函数字面量中的建议修复，循环体中的 continue 改为 return。
+// 循环体中有 return、跳出这个循环的 break、带标签的跳转或多行的原始字符串时返回 nil
+func loopClosureFix(pass *analysis.Pass, body *ast.BlockStmt) []analysis.SuggestedFix {
+	var continues []token.Pos
+	ok := true
+	inspectStack(body, func(n ast.Node, stack []ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			ok = false
+		case *ast.BasicLit:
+			if n.Kind == token.STRING && strings.Contains(n.Value, "\n") {
+				ok = false
+			}
+		case *ast.BranchStmt:
+			if n.Tok == token.FALLTHROUGH {
+				break
+			}
+			if n.Label != nil || n.Tok == token.GOTO {
+				ok = false
+				break
+			}
+			// 不带标签的 break、continue 跳转到最内层的语句，stack 从循环体开始
+			inner := false
+			for _, s := range stack {
+				switch s.(type) {
+				case *ast.ForStmt, *ast.RangeStmt:
+					inner = true
+				case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+					inner = inner || n.Tok == token.BREAK
+				}
+			}
+			switch {
+			case inner:
+			case n.Tok == token.CONTINUE:
+				continues = append(continues, n.Pos())
+			default:
+				ok = false
+			}
+		}
+		return ok
+	})
+	tf := pass.Fset.File(body.Pos())
+	if !ok || tf.Line(body.Lbrace) == tf.Line(body.Rbrace) {
+		return nil
+	}
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return nil
+	}
+	start := tf.Offset(body.Lbrace) + 1
+	text := string(src[start:tf.Offset(body.Rbrace)])
+	// 从后往前替换，前面的偏移不受影响
+	for i := len(continues) - 1; i >= 0; i-- {
+		off := tf.Offset(continues[i]) - start
+		text = text[:off] + "return" + text[off+len("continue"):]
+	}
+	// 第一行是 { 之后的内容，最后一行是 } 之前的缩进，中间的每一行多缩进一级
+	lines := strings.Split(text, "\n")
+	indent := lineIndent(pass, body.Rbrace)
+	var b strings.Builder
+	b.WriteString(lines[0] + "\n" + indent + "\tfunc() {\n")
+	for _, line := range lines[1 : len(lines)-1] {
+		if strings.TrimSpace(line) != "" {
+			line = "\t" + line
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(indent + "\t}()\n" + indent)
+	return []analysis.SuggestedFix{{
+		Message: i18n.T("把循环体放进立即调用的函数字面量中"),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     body.Lbrace + 1,
+			End:     body.Rbrace,
+			NewText: []byte(b.String()),
+		}},
+	}}
+}
+
+// errorType 是预声明的 error 接口
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// isError 报告 t 是否实现了 error 接口，nil 和无类型常量不算
+func isError(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	if b, ok := t.(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+		return false
+	}
+	return types.Implements(t, errorType)
+}
+
+// calledFunc 返回直接调用的函数或方法，调用函数值等其他形式时返回 nil
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	}
+	if id == nil {
+		return nil
+	}
+	fn, _ := pass.TypesInfo.Uses[id].(*types.Func)
+	return fn
+}
+
+// errorfFormat 返回 Errorf 调用的格式字符串。fmt.Errorf 和签名与它相同、同样名为 Errorf 的函数
+// （比如 i18n.Errorf）都算，格式字符串不是常量时返回 false
+func errorfFormat(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	fn := calledFunc(pass, call)
+	if fn == nil || fn.Name() != "Errorf" || len(call.Args) == 0 {
+		return "", false
+	}
+	sig := fn.Type().(*types.Signature)
+	if !sig.Variadic() || sig.Params().Len() != 2 || sig.Results().Len() != 1 || !types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type()) {
+		return "", false
+	}
+	if b, ok := sig.Params().At(0).Type().(*types.Basic); !ok || b.Kind() != types.String {
+		return "", false
+	}
+	c := pass.TypesInfo.Types[call.Args[0]].Value
+	if c == nil || c.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(c), true
+}
+
+// formatVerbs 返回格式字符串中依次对应每个参数的动词和动词在格式字符串中的偏移。
+// 有 [n] 显式指定参数或 * 宽度时无法确定对应关系，返回 false
+func formatVerbs(format string) (verbs []rune, offsets []int, ok bool) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0123456789.", format[i]) >= 0 {
+			i++
+		}
+		if i >= len(format) {
+			break
+		}
+		switch format[i] {
+		case '%':
+			continue
+		case '[', '*':
+			return nil, nil, false
+		}
+		verbs = append(verbs, rune(format[i]))
+		offsets = append(offsets, i)
+	}
+	return verbs, offsets, true
+}
+
+// wrappingFuncs 找出包中返回 %w 包装的错误的函数：return 语句返回格式字符串中有 %w 的 Errorf，
+// 或返回另一个这样的函数的调用结果。返回的是局部变量或命名返回值时，看它在 return 之前最后一次赋值的调用
+func wrappingFuncs(pass *analysis.Pass) map[*types.Func]bool {
+	returns := make(map[*types.Func][]*ast.CallExpr)
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		// 命名的 error 返回值，用于没有写出返回值的 return
+		var named []ast.Expr
+		if decl.Type.Results != nil {
+			for _, field := range decl.Type.Results.List {
+				for _, name := range field.Names {
+					if isError(pass.TypesInfo.TypeOf(name)) {
+						named = append(named, name)
+					}
+				}
+			}
+		}
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				results := n.Results
+				if len(results) == 0 {
+					results = named
+				}
+				for _, r := range results {
+					if !isError(pass.TypesInfo.TypeOf(r)) {
+						continue
+					}
+					if call := errorCall(pass, decl.Body, r, n.Pos()); call != nil {
+						returns[fn] = append(returns[fn], call)
+					}
+				}
+			}
+			return true
+		})
+	})
+	wrapping := make(map[*types.Func]bool)
+	// 反复传播，直到没有新的函数被标记
+	for changed := true; changed; {
+		changed = false
+		for fn, calls := range returns {
+			for _, call := range calls {
+				if wrapping[fn] {
+					break
+				}
+				format, ok := errorfFormat(pass, call)
+				verbs, _, _ := formatVerbs(format)
+				if ok && strings.ContainsRune(string(verbs), 'w') || wrapping[calledFunc(pass, call)] {
+					wrapping[fn], changed = true, true
+				}
+			}
+		}
+	}
+	return wrapping
+}
+
+// checkErrorCompare 报告用 == 或 != 比较错误：另一边是 errors.New、Errorf 的调用时永远不相等；
+// 另一边是包级的哨兵错误、而错误来自 wrapping 中的函数时，== 不会检查包装在里面的错误
+func checkErrorCompare(pass *analysis.Pass, body *ast.BlockStmt, b *ast.BinaryExpr, wrapping map[*types.Func]bool) {
+	if b.Op != token.EQL && b.Op != token.NEQ || !isError(pass.TypesInfo.TypeOf(b.X)) || !isError(pass.TypesInfo.TypeOf(b.Y)) {
+		return
+	}
+	for _, pair := range [][2]ast.Expr{{b.X, b.Y}, {b.Y, b.X}} {
+		x, target := pair[0], pair[1]
+		if call, ok := ast.Unparen(target).(*ast.CallExpr); ok {
+			if _, isErrorf := errorfFormat(pass, call); isErrorf || isFunc(calledFunc(pass, call), "errors", "New") {
+				report(pass, errEqualCompare, b, nil,
+					"每次调用 %s 都会创建新的错误值，%s 永远不会与它相等；把错误定义成包级变量，再用 errors.Is 比较",
+					types.ExprString(call.Fun), types.ExprString(x))
+				return
+			}
+		}
+		if !isSentinel(pass, target) {
+			continue
+		}
+		fn := errorSource(pass, body, x, b.Pos())
+		if !wrapping[fn] {
+			continue
+		}
+		file := fileOf(pass, b.Pos())
+		name, edit := importStd(pass, file, "errors")
+		fix := name + ".Is(" + sourceText(pass, x) + ", " + sourceText(pass, target) + ")"
+		if b.Op == token.NEQ {
+			fix = "!" + fix
+		}
+		edits := []analysis.TextEdit{{Pos: b.Pos(), End: b.End(), NewText: []byte(fix)}}
+		if edit != nil {
+			edits = append(edits, *edit)
+		}
+		report(pass, errEqualCompare, b, []analysis.SuggestedFix{{
+			Message:   i18n.Sprintf("改为 %s", fix),
+			TextEdits: edits,
+		}},
+			"%s 返回的错误可能用 %%w 包装过，%s 只比较最外层的错误，不会检查包装在里面的 %s；改用 %s",
+			fn.Name(), b.Op, types.ExprString(target), fix)
+		return
+	}
+}
+
+// isFunc 报告 fn 是否是包 pkg 中名为 name 的函数
+func isFunc(fn *types.Func, pkg, name string) bool {
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == pkg && fn.Name() == name
+}
+
+// isSentinel 报告 e 是否引用包级的错误变量，比如 io.EOF
+func isSentinel(pass *analysis.Pass, e ast.Expr) bool {
+	var id *ast.Ident
+	switch x := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		id = x
+	case *ast.SelectorExpr:
+		id = x.Sel
+	}
+	if id == nil {
+		return false
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	return ok && !v.IsField() && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+// errorSource 返回错误 e 来自的函数：e 是函数调用，或是在 pos 之前最后一次由函数调用赋值的局部变量，
+// 都不是时返回 nil
+func errorSource(pass *analysis.Pass, body *ast.BlockStmt, e ast.Expr, pos token.Pos) *types.Func {
+	if call := errorCall(pass, body, e, pos); call != nil {
+		return calledFunc(pass, call)
+	}
+	return nil
+}
+
+// errorCall 返回错误 e 来自的函数调用：e 本身是调用，或是在 pos 之前最后一次由函数调用赋值的局部变量，
+// 都不是时返回 nil
+func errorCall(pass *analysis.Pass, body *ast.BlockStmt, e ast.Expr, pos token.Pos) *ast.CallExpr {
+	if call, ok := ast.Unparen(e).(*ast.CallExpr); ok {
+		return call
+	}
+	v := localVar(pass, e)
+	if v == nil {
+		return nil
+	}
+	var src *ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		as, ok := n.(*ast.AssignStmt)
+		if !ok || as.Pos() >= pos {
+			return n == nil || n.Pos() < pos
+		}
+		for i, lhs := range as.Lhs {
+			if localVar(pass, lhs) != v {
+				continue
+			}
+			// x, err := f() 或 err := f()，其他形式的赋值不知道错误来自哪里
+			var rhs ast.Expr
+			if len(as.Rhs) == len(as.Lhs) {
+				rhs = as.Rhs[i]
+			} else if len(as.Rhs) == 1 {
+				rhs = as.Rhs[0]
+			}
+			src, _ = ast.Unparen(rhs).(*ast.CallExpr)
+		}
+		return true
+	})
+	return src
+}
+
+// checkWrapVerb 报告 Errorf 中用 %v 或 %s 格式化的错误参数：格式字符串中没有 %w 时，
+// 返回的错误只保留了原始错误的信息，调用方无法再用 errors.Is 或 errors.As 检查原始错误
+func checkWrapVerb(pass *analysis.Pass, call *ast.CallExpr) {
+	format, ok := errorfFormat(pass, call)
+	if !ok || call.Ellipsis.IsValid() {
+		return
+	}
+	verbs, offsets, ok := formatVerbs(format)
+	if !ok || strings.ContainsRune(string(verbs), 'w') {
+		return
+	}
+	for i, arg := range call.Args[1:] {
+		if i >= len(verbs) || verbs[i] != 'v' && verbs[i] != 's' || !isError(pass.TypesInfo.TypeOf(arg)) {
+			continue
+		}
+		var fixes []analysis.SuggestedFix
+		// 格式字符串是没有转义字符的字面量时，源代码中的偏移与格式字符串中的相同；带标志的 %+v 等不修复
+		lit, ok := ast.Unparen(call.Args[0]).(*ast.BasicLit)
+		if ok && lit.Value[1:len(lit.Value)-1] == format && format[offsets[i]-1] == '%' {
+			pos := lit.Pos() + 1 + token.Pos(offsets[i])
+			fixes = []analysis.SuggestedFix{{
+				Message:   i18n.T("改用 %w 包装"),
+				TextEdits: []analysis.TextEdit{{Pos: pos, End: pos + 1, NewText: []byte("w")}},
+			}}
+		}
+		report(pass, errWrapVerb, arg, fixes,
+			"%s 是 error，用 %%%c 格式化只保留了它的信息，调用方无法再用 errors.Is 或 errors.As 检查原始错误；改用 %%w 包装",
+			types.ExprString(arg), verbs[i])
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var goroutineClosure = newAnalyzer("goroutine_closure",
+	"检查循环中启动的 goroutine 通过闭包修改循环外的变量，以及 Go 1.22 之前捕获循环变量",
+	runGoroutineClosure)
+
+func runGoroutineClosure(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+			lit, ok := n.(*ast.FuncLit)
+			if !ok || len(stack) < 2 || !goroutineLit(lit, stack[len(stack)-1], stack[len(stack)-2]) {
+				return true
+			}
+			// 只看同一个函数中离 go 语句最近的循环
+			var loop ast.Node
+			for i := len(stack) - 1; i >= 0; i-- {
+				if _, ok := stack[i].(*ast.FuncLit); ok {
+					break
+				}
+				if isLoop(stack[i]) {
+					loop = stack[i]
+					break
+				}
+			}
+			if loop == nil {
+				return true
+			}
+			checkClosure(pass, lit, loop)
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// checkClosure 检查循环 loop 中每次迭代启动的 goroutine lit
+func checkClosure(pass *analysis.Pass, lit *ast.FuncLit, loop ast.Node) {
+	if v := fileVersion(pass, lit.Pos()); v != "" && version.Compare(v, "go1.22") < 0 {
+		for _, lv := range loopVars(pass, loop) {
+			if refersTo(pass, lit.Body, lv) {
+				report(pass, "goroutine_closure", lit, nil,
+					"goroutine 捕获了循环变量 %s，Go 1.22 之前所有迭代共享同一个变量，goroutine 读到的可能是循环结束后的值；把 %s 作为参数传给 goroutine",
+					lv.Name(), lv.Name())
+			}
+		}
+	}
+
+	if callsLock(lit.Body) {
+		return
+	}
+	reported := make(map[*types.Var]bool)
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		var lhs []ast.Expr
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			lhs = n.Lhs
+		case *ast.IncDecStmt:
+			lhs = []ast.Expr{n.X}
+		}
+		for _, e := range lhs {
+			v := localVar(pass, e)
+			// 只报告在循环之前声明的变量，循环体内声明的变量每次迭代都是新的
+			if v == nil || reported[v] || v.Pos() >= loop.Pos() {
+				continue
+			}
+			reported[v] = true
+			report(pass, "goroutine_closure", e, nil,
+				"循环中启动的多个 goroutine 同时修改循环外的变量 %s，存在数据竞争；用 sync.Mutex、atomic 保护，或让每个 goroutine 返回自己的结果",
+				v.Name())
+		}
+		return true
+	})
+}
+
+// loopVars 返回 for 或 range 语句声明的循环变量
+func loopVars(pass *analysis.Pass, loop ast.Node) []*types.Var {
+	var idents []ast.Expr
+	switch loop := loop.(type) {
+	case *ast.ForStmt:
+		if init, ok := loop.Init.(*ast.AssignStmt); ok {
+			idents = init.Lhs
+		}
+	case *ast.RangeStmt:
+		idents = []ast.Expr{loop.Key, loop.Value}
+	}
+	var vars []*types.Var
+	for _, e := range idents {
+		if id, ok := e.(*ast.Ident); ok {
+			if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok {
+				vars = append(vars, v)
+			}
+		}
+	}
+	return vars
+}
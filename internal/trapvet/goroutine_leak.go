@@ -0,0 +1,225 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var goroutineLeak = newAnalyzer("goroutine_leak",
+	"检查只阻塞在局部通道上的 goroutine：通道没有传出函数，函数中也没有与之配对的发送、接收或关闭；以及调用方只在带超时的 select 中接收、超时后再没有人接收的无缓冲通道上的发送",
+	runGoroutineLeak)
+
+// goroutine_leak 的子陷阱
+const (
+	leakUnmatched       = "goroutine_leak/unmatched"        // 通道上没有配对的操作
+	leakAbandonedSender = "goroutine_leak/abandoned_sender" // 接收方超时返回后，发送方永远阻塞
+)
+
+func runGoroutineLeak(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		chans := localChans(pass, decl.Body)
+		if len(chans) == 0 {
+			return
+		}
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			g, ok := n.(*ast.GoStmt)
+			if !ok {
+				return true
+			}
+			lit, ok := g.Call.Fun.(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			for v, use := range chans {
+				if !use.escapes {
+					checkAbandonedSender(pass, decl.Body, lit, v, use)
+				}
+			}
+			v, op := onlyBlockingChan(pass, lit.Body)
+			use := chans[v]
+			if use == nil || use.escapes {
+				return true
+			}
+			checkUnmatched(pass, decl.Body, g, v, use, op)
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// onlyBlockingChan 返回 goroutine 中唯一会阻塞的通道和第一次对它的操作（发送、接收或 range）。
+// goroutine 中还有 select、对其他通道的操作、Wait 或 Lock 时返回 nil
+func onlyBlockingChan(pass *analysis.Pass, body *ast.BlockStmt) (*types.Var, ast.Node) {
+	var v *types.Var
+	var first ast.Node
+	ok := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		var ch ast.Expr
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.SelectStmt:
+			// 嵌套的函数字面量不一定在这个 goroutine 中执行；select 可能在多个通道上等待
+			ok = false
+		case *ast.SendStmt:
+			ch = n.Chan
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				ch = n.X
+			}
+		case *ast.RangeStmt:
+			if _, isChan := pass.TypesInfo.TypeOf(n.X).Underlying().(*types.Chan); isChan {
+				ch = n.X
+			}
+		case *ast.CallExpr:
+			if sel, isSel := n.Fun.(*ast.SelectorExpr); isSel && (sel.Sel.Name == "Wait" || sel.Sel.Name == "Lock" || sel.Sel.Name == "RLock") {
+				ok = false
+			}
+		}
+		if ch != nil {
+			cv := localVar(pass, ch)
+			if cv == nil || v != nil && cv != v {
+				ok = false
+			} else if v == nil {
+				v, first = cv, n
+			}
+		}
+		return ok
+	})
+	if !ok {
+		return nil, nil
+	}
+	return v, first
+}
+
+// checkUnmatched 报告 goroutine g 对通道 v 的操作 op 在 g 之外没有配对的操作：
+// 接收没有对应的发送或关闭，向无缓冲通道的发送没有对应的接收
+func checkUnmatched(pass *analysis.Pass, body *ast.BlockStmt, g *ast.GoStmt, v *types.Var, use *chanUse, op ast.Node) {
+	events, _ := chanEvents(pass, body, v)
+	_, send := op.(*ast.SendStmt)
+	if send && buffered(pass, use) {
+		return // 带缓冲的通道上的发送不一定阻塞
+	}
+	for _, ev := range events {
+		if ev.g == g {
+			continue
+		}
+		switch {
+		case send && ev.op == opRecv:
+			return
+		case !send && (ev.op == opSend || ev.op == opClose):
+			return
+		}
+	}
+	if send {
+		report(pass, leakUnmatched, op, nil,
+			"goroutine 阻塞在向无缓冲通道 %s 发送，%s 没有传出这个函数，函数中也没有其他地方从它接收，这个 goroutine 永远不会退出；确保有接收方，或改用带缓冲的通道",
+			v.Name(), v.Name())
+	} else {
+		report(pass, leakUnmatched, op, nil,
+			"goroutine 阻塞在从通道 %s 接收，%s 没有传出这个函数，函数中也没有其他地方向它发送或关闭它，这个 goroutine 永远不会退出；发送数据或关闭通道，或用 context、done 通道通知 goroutine 退出",
+			v.Name(), v.Name())
+	}
+}
+
+// buffered 报告通道是否可能带缓冲：make 的容量参数不是常量 0
+func buffered(pass *analysis.Pass, use *chanUse) bool {
+	if len(use.make.Args) < 2 {
+		return false
+	}
+	c := pass.TypesInfo.Types[use.make.Args[1]].Value
+	return c == nil || constant.Sign(c) != 0
+}
+
+// checkAbandonedSender 检查 goroutine lit 直接向无缓冲通道 v 发送结果，而 g 之外对 v 的接收都在不在循环中、
+// 还有超时等其他分支的 select 里：select 走了其他分支返回后，再也没有人接收，发送方永远阻塞
+func checkAbandonedSender(pass *analysis.Pass, body *ast.BlockStmt, lit *ast.FuncLit, v *types.Var, use *chanUse) {
+	if buffered(pass, use) {
+		return
+	}
+	var sends []*ast.SendStmt
+	inSelect, inLoop := false, false
+	inspectStack(lit.Body, func(n ast.Node, stack []ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SendStmt:
+			if localVar(pass, n.Chan) != v {
+				break
+			}
+			// select 中的发送可以同时等待 ctx.Done() 等退出信号
+			if _, ok := stack[len(stack)-1].(*ast.CommClause); ok {
+				inSelect = true
+			}
+			inLoop = inLoop || enclosingLoop(stack) != nil
+			sends = append(sends, n)
+		}
+		return true
+	})
+	if len(sends) == 0 || inSelect {
+		return
+	}
+	var sel *ast.SelectStmt
+	abandoned := true
+	inspectStack(body, func(n ast.Node, stack []ast.Node) bool {
+		if n == lit {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.RangeStmt:
+			if localVar(pass, n.X) == v {
+				abandoned = false
+			}
+		case *ast.UnaryExpr:
+			if n.Op != token.ARROW || localVar(pass, n.X) != v {
+				break
+			}
+			s := timeoutSelect(stack)
+			if s == nil {
+				abandoned = false
+			} else if sel == nil {
+				sel = s
+			}
+		}
+		return abandoned
+	})
+	if !abandoned || sel == nil {
+		return
+	}
+	var fixes []analysis.SuggestedFix
+	if len(sends) == 1 && !inLoop {
+		fixes = []analysis.SuggestedFix{{
+			Message: i18n.Sprintf("改为 make(%s, 1)", sourceText(pass, use.make.Args[0])),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     use.make.Rparen,
+				End:     use.make.Rparen,
+				NewText: []byte(", 1"),
+			}},
+		}}
+	}
+	report(pass, leakAbandonedSender, sends[0], fixes,
+		"%s 是无缓冲通道，调用方只在第 %d 行的 select 中接收，select 走了超时等其他分支返回后再也没有人接收，这里的发送会永远阻塞；用 make(%s, 1) 创建带一个缓冲的通道，或在 select 中同时等待 ctx.Done() 再发送",
+		v.Name(), pass.Fset.Position(sel.Pos()).Line, sourceText(pass, use.make.Args[0]))
+}
+
+// timeoutSelect 返回接收操作所在的、不在循环中并且还有其他分支的 select 语句。
+// stack 是接收表达式的父节点路径，接收不是 select 的某个分支时返回 nil
+func timeoutSelect(stack []ast.Node) *ast.SelectStmt {
+	if len(stack) < 4 {
+		return nil
+	}
+	comm := stack[len(stack)-1]
+	cc, ok := stack[len(stack)-2].(*ast.CommClause)
+	if !ok || cc.Comm != comm {
+		return nil
+	}
+	sel, ok := stack[len(stack)-4].(*ast.SelectStmt)
+	if !ok || len(sel.Body.List) < 2 || enclosingLoop(stack[:len(stack)-4]) != nil {
+		return nil
+	}
+	return sel
+}
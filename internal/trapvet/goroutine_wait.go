@@ -0,0 +1,128 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var goroutineWait = newAnalyzer("goroutine_wait",
+	"检查在循环中启动 goroutine 后既不等待也不通信就返回的函数",
+	runGoroutineWait)
+
+func runGoroutineWait(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		var spawned []*ast.GoStmt
+		inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+			g, ok := n.(*ast.GoStmt)
+			if !ok {
+				return true
+			}
+			// go f(...) 调用的函数在别处定义，无法判断它是否会同步
+			if _, ok := g.Call.Fun.(*ast.FuncLit); ok && inLoop(stack) && !synchronizes(pass, g.Call) {
+				spawned = append(spawned, g)
+			}
+			return true
+		})
+		if len(spawned) == 0 || waitsAfter(pass, decl.Body, spawned[0].Pos()) {
+			return
+		}
+		for _, g := range spawned {
+			report(pass, "goroutine_wait", g, nil,
+				"%s 在循环中启动了 goroutine，却没有等待它们完成就返回，程序退出时这些 goroutine 会被直接终止；用 sync.WaitGroup 等待或通过通道收集结果",
+				decl.Name.Name)
+		}
+	})
+	return nil, nil
+}
+
+// inLoop 报告 stack 的末尾（同一个函数内）是否有循环
+func inLoop(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		}
+	}
+	return false
+}
+
+// synchronizes 报告 goroutine 是否会与外界同步：使用通道、select，
+// 或引用了 WaitGroup、Mutex、context 等同步工具
+func synchronizes(pass *analysis.Pass, call *ast.CallExpr) bool {
+	found := false
+	ast.Inspect(call, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SendStmt, *ast.SelectStmt:
+			found = true
+		case *ast.UnaryExpr:
+			found = n.Op == token.ARROW
+		case *ast.Ident:
+			if v, ok := pass.TypesInfo.ObjectOf(n).(*types.Var); ok {
+				found = isSyncType(v.Type())
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isSyncType 报告 t 是否是通道、sync 包中的类型或 context.Context（及其指针）
+func isSyncType(t types.Type) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if _, ok := t.Underlying().(*types.Chan); ok {
+		return true
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	switch named.Obj().Pkg().Path() {
+	case "sync", "sync/atomic", "context":
+		return true
+	}
+	return false
+}
+
+// waitsAfter 报告函数体在 pos 之后是否有阻塞等待：接收通道、select、range 通道、
+// 调用 Wait 方法或 time.Sleep
+func waitsAfter(pass *analysis.Pass, body *ast.BlockStmt, pos token.Pos) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found || n == nil {
+			return false
+		}
+		if _, ok := n.(*ast.GoStmt); ok {
+			return false
+		}
+		if n.Pos() < pos {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.SelectStmt:
+			found = true
+		case *ast.UnaryExpr:
+			found = n.Op == token.ARROW
+		case *ast.RangeStmt:
+			_, found = pass.TypesInfo.TypeOf(n.X).Underlying().(*types.Chan)
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				if sel.Sel.Name == "Wait" {
+					found = true
+				}
+				if fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func); ok && fn.Pkg() != nil &&
+					fn.Pkg().Path() == "time" && fn.Name() == "Sleep" {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,430 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var interfaceAssertion = newAnalyzer("interface_assertion",
+	"检查没有检查 ok、类型也没有被之前的检查确定的单值类型断言 x.(T)",
+	runInterfaceAssertion)
+
+func runInterfaceAssertion(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+			ta, ok := n.(*ast.TypeAssertExpr)
+			if !ok || ta.Type == nil || commaOk(ta, stack) || assertionProven(pass, decl.Body, ta, stack) {
+				return true
+			}
+			report(pass, "interface_assertion", ta, assertionFix(pass, decl, ta, stack),
+				"类型断言 %s 没有检查是否成功，%s 的动态类型不是 %s 时会 panic: interface conversion；改用 v, ok := %s 并处理 ok 为 false 的情况，需要区分多种类型时用 type switch",
+				types.ExprString(ta), types.ExprString(ta.X), typeString(pass, pass.TypesInfo.TypeOf(ta.Type)), types.ExprString(ta))
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// commaOk 报告类型断言是否是 v, ok := x.(T) 形式
+func commaOk(ta *ast.TypeAssertExpr, stack []ast.Node) bool {
+	i := len(stack) - 1
+	for i >= 0 {
+		if _, ok := stack[i].(*ast.ParenExpr); !ok {
+			break
+		}
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	switch p := stack[i].(type) {
+	case *ast.AssignStmt:
+		return len(p.Lhs) == 2 && len(p.Rhs) == 1
+	case *ast.ValueSpec:
+		return len(p.Names) == 2 && len(p.Values) == 1
+	}
+	return false
+}
+
+// sameAssertion 报告 a 是否断言与 ta 相同的表达式和类型
+func sameAssertion(pass *analysis.Pass, a, ta *ast.TypeAssertExpr) bool {
+	return a.Type != nil && types.ExprString(a.X) == types.ExprString(ta.X) &&
+		types.Identical(pass.TypesInfo.TypeOf(a.Type), pass.TypesInfo.TypeOf(ta.Type))
+}
+
+// assertionProven 报告类型断言 ta 的类型是否已经被支配它的检查确定：
+// 在 if _, ok := x.(T); ok 的分支中、在 type switch 的 case T 中，或者之前有 if !ok { return } 这样的提前返回，
+// 并且从检查到断言之间 x 没有被重新赋值
+func assertionProven(pass *analysis.Pass, body *ast.BlockStmt, ta *ast.TypeAssertExpr, stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var child ast.Node = ta
+		if i+1 < len(stack) {
+			child = stack[i+1]
+		}
+		switch s := stack[i].(type) {
+		case *ast.IfStmt:
+			if child == s.Body && okCondition(pass, body, s.Cond, ta, false) ||
+				child == s.Else && okCondition(pass, body, s.Cond, ta, true) {
+				return true
+			}
+		case *ast.CaseClause:
+			if i < 2 || len(s.List) != 1 {
+				break
+			}
+			if ts, ok := stack[i-2].(*ast.TypeSwitchStmt); ok {
+				if x := typeSwitchOperand(ts); x != nil && types.ExprString(x) == types.ExprString(ta.X) &&
+					types.Identical(pass.TypesInfo.TypeOf(s.List[0]), pass.TypesInfo.TypeOf(ta.Type)) &&
+					!assignedBetween(pass, body, ta.X, ts.Assign.End(), ta.Pos()) {
+					return true
+				}
+			}
+		}
+		var list []ast.Stmt
+		switch s := stack[i].(type) {
+		case *ast.BlockStmt:
+			list = s.List
+		case *ast.CaseClause:
+			list = s.Body
+		case *ast.CommClause:
+			list = s.Body
+		}
+		for _, stmt := range list {
+			if stmt == child {
+				break
+			}
+			if ifs, ok := stmt.(*ast.IfStmt); ok && ifs.Else == nil && terminates(ifs.Body) &&
+				okCondition(pass, body, ifs.Cond, ta, true) {
+				return true
+			}
+		}
+	}
+	return onlyHoldsType(pass, ta)
+}
+
+// onlyHoldsType 报告 ta 断言的变量 x 是否只被赋过静态类型为 T 的值（包括声明时的初始值），
+// 这时断言一定成功，如 var v any = 42 之后的 v.(int)
+func onlyHoldsType(pass *analysis.Pass, ta *ast.TypeAssertExpr) bool {
+	id, ok := ast.Unparen(ta.X).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || v.IsField() || v.Pkg() != pass.Pkg {
+		return false
+	}
+	want := pass.TypesInfo.TypeOf(ta.Type)
+	holds := func(e ast.Expr) bool {
+		return types.Identical(types.Default(pass.TypesInfo.TypeOf(e)), want)
+	}
+	initialized, ok := false, true
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if pass.TypesInfo.Defs[name] == v {
+						initialized = true
+						ok = ok && len(n.Values) == len(n.Names) && holds(n.Values[i])
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					if lid, isIdent := lhs.(*ast.Ident); isIdent && pass.TypesInfo.ObjectOf(lid) == v {
+						initialized = initialized || n.Tok == token.DEFINE && pass.TypesInfo.Defs[lid] == v
+						ok = ok && len(n.Lhs) == len(n.Rhs) && holds(n.Rhs[i])
+					}
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND && pass.TypesInfo.ObjectOf(identOf(n.X)) == v {
+					ok = false
+				}
+			case *ast.RangeStmt:
+				if pass.TypesInfo.ObjectOf(identOf(n.Key)) == v || pass.TypesInfo.ObjectOf(identOf(n.Value)) == v {
+					ok = false
+				}
+			}
+			return ok
+		})
+	}
+	return initialized && ok
+}
+
+// typeSwitchOperand 返回 type switch 判断的表达式 x.(type) 中的 x
+func typeSwitchOperand(ts *ast.TypeSwitchStmt) ast.Expr {
+	var e ast.Expr
+	switch s := ts.Assign.(type) {
+	case *ast.ExprStmt:
+		e = s.X
+	case *ast.AssignStmt:
+		if len(s.Rhs) == 1 {
+			e = s.Rhs[0]
+		}
+	}
+	if a, ok := ast.Unparen(e).(*ast.TypeAssertExpr); ok {
+		return a.X
+	}
+	return nil
+}
+
+// okCondition 报告条件 cond 成立（negated 为 true 时不成立）是否意味着 ta 的断言会成功：
+// cond 是 ok 或 ok && ...（negated 时是 !ok 或 !ok || ...），且 ok 最近一次由 x.(T) 的 comma-ok 形式赋值
+func okCondition(pass *analysis.Pass, body *ast.BlockStmt, cond ast.Expr, ta *ast.TypeAssertExpr, negated bool) bool {
+	cond = ast.Unparen(cond)
+	if b, ok := cond.(*ast.BinaryExpr); ok {
+		if !negated && b.Op == token.LAND || negated && b.Op == token.LOR {
+			return okCondition(pass, body, b.X, ta, negated) || okCondition(pass, body, b.Y, ta, negated)
+		}
+		return false
+	}
+	if negated {
+		u, ok := cond.(*ast.UnaryExpr)
+		if !ok || u.Op != token.NOT {
+			return false
+		}
+		cond = ast.Unparen(u.X)
+	}
+	id, ok := cond.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	okVar, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok {
+		return false
+	}
+	// 找到条件之前最近一次给 ok 赋值的语句
+	var last *ast.AssignStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		as, ok := n.(*ast.AssignStmt)
+		if !ok || as.Pos() >= cond.Pos() || last != nil && as.Pos() < last.Pos() {
+			return true
+		}
+		for _, lhs := range as.Lhs {
+			if lid, ok := lhs.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(lid) == okVar {
+				last = as
+			}
+		}
+		return true
+	})
+	if last == nil || len(last.Lhs) != 2 || len(last.Rhs) != 1 {
+		return false
+	}
+	a, ok := ast.Unparen(last.Rhs[0]).(*ast.TypeAssertExpr)
+	lid, _ := last.Lhs[1].(*ast.Ident)
+	return ok && lid != nil && pass.TypesInfo.ObjectOf(lid) == okVar && sameAssertion(pass, a, ta) &&
+		!assignedBetween(pass, body, ta.X, last.End(), ta.Pos())
+}
+
+// assignedBetween 报告 body 中位于 from 和 to 之间的语句是否可能给 x 赋值。
+// x 是局部变量时还包括取地址和 range 赋值；其他表达式只比较赋值语句的左侧
+func assignedBetween(pass *analysis.Pass, body *ast.BlockStmt, x ast.Expr, from, to token.Pos) bool {
+	v := localVar(pass, x)
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found || n == nil || n.End() <= from || n.Pos() >= to {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt, *ast.IncDecStmt, *ast.UnaryExpr, *ast.RangeStmt:
+			if n.Pos() < from {
+				break
+			}
+			if v != nil {
+				found = assigns(pass, n, v)
+			} else if as, ok := n.(*ast.AssignStmt); ok {
+				for _, lhs := range as.Lhs {
+					found = found || types.ExprString(lhs) == types.ExprString(x)
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// assertionFix 返回把 ta 改为 v, ok := x.(T) 并在 ok 为 false 时返回的建议修复。
+// v := x.(T) 直接改写；其他语句中的断言提到语句之前，原来的位置改用 v。无法安全改写时返回 nil
+func assertionFix(pass *analysis.Pass, decl *ast.FuncDecl, ta *ast.TypeAssertExpr, stack []ast.Node) []analysis.SuggestedFix {
+	// 找到断言所在的、直接位于语句列表中的语句
+	j := -1
+	for i := len(stack) - 1; i >= 1; i-- {
+		if _, ok := stack[i].(ast.Stmt); !ok {
+			continue
+		}
+		switch stack[i-1].(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			j = i
+		}
+		if j >= 0 {
+			break
+		}
+	}
+	if j < 0 {
+		return nil
+	}
+	stmt := stack[j].(ast.Stmt)
+	switch stmt.(type) {
+	case *ast.ExprStmt, *ast.AssignStmt, *ast.ReturnStmt, *ast.DeclStmt, *ast.SendStmt, *ast.IncDecStmt, *ast.GoStmt, *ast.DeferStmt:
+	default:
+		return nil // 提到 if、for 等语句之前会改变求值的时机和次数
+	}
+	for i := j + 1; i < len(stack); i++ {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit:
+			return nil
+		case *ast.BinaryExpr:
+			// && 和 || 的右侧不一定求值
+			if (n.Op == token.LAND || n.Op == token.LOR) && (i+1 < len(stack) && stack[i+1] == n.Y || i+1 == len(stack) && n.Y == ta) {
+				return nil
+			}
+		}
+	}
+
+	// 所在函数的签名：语句外层最近的函数字面量，或者函数声明本身
+	sig, _ := pass.TypesInfo.Defs[decl.Name].Type().(*types.Signature)
+	for i := j - 1; i >= 0; i-- {
+		if lit, ok := stack[i].(*ast.FuncLit); ok {
+			sig, _ = pass.TypesInfo.TypeOf(lit).(*types.Signature)
+			break
+		}
+	}
+	if sig == nil {
+		return nil
+	}
+	file := fileOf(pass, ta.Pos())
+	if file == nil {
+		return nil
+	}
+	scope := pass.Pkg.Scope().Innermost(stmt.Pos())
+	okName := freeName(scope, stmt.Pos(), "ok", ta.X, func(obj types.Object) bool {
+		// 同一作用域中已有的 bool 变量 ok 可以直接复用
+		return obj.Parent() == scope && !types.Identical(obj.Type(), types.Typ[types.Bool])
+	})
+
+	var edits []analysis.TextEdit
+	ret, fmtEdit, ok := assertionReturn(pass, file, sig, ta)
+	if !ok {
+		return nil
+	}
+	if fmtEdit != nil {
+		edits = append(edits, *fmtEdit)
+	}
+	indent := lineIndent(pass, stmt.Pos())
+	branch := "if !" + okName + " {\n" + indent + "\t" + ret + "\n" + indent + "}"
+
+	var name string
+	if as, ok := stmt.(*ast.AssignStmt); ok && as.Tok == token.DEFINE && len(as.Lhs) == 1 && ast.Unparen(as.Rhs[0]) == ta && !isBlank(as.Lhs[0]) {
+		name = types.ExprString(as.Lhs[0])
+		edits = append(edits,
+			analysis.TextEdit{Pos: as.Lhs[0].End(), End: as.Lhs[0].End(), NewText: []byte(", " + okName)},
+			afterLine(pass, stmt.End(), indent+branch))
+	} else {
+		name = freeName(scope, stmt.Pos(), assertionVarName(pass.TypesInfo.TypeOf(ta.Type)), ta.X, func(types.Object) bool { return true })
+		edits = append(edits,
+			analysis.TextEdit{Pos: stmt.Pos(), End: stmt.Pos(), NewText: []byte(name + ", " + okName + " := " + types.ExprString(ta) + "\n" + indent + branch + "\n" + indent)},
+			analysis.TextEdit{Pos: ta.Pos(), End: ta.End(), NewText: []byte(name)})
+	}
+	return []analysis.SuggestedFix{{
+		Message:   i18n.Sprintf("改为 %s, %s := %s 并检查 %s", name, okName, types.ExprString(ta), okName),
+		TextEdits: edits,
+	}}
+}
+
+func isBlank(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+// freeName 返回以 base 开头、在 pos 处不与 conflicts 报告冲突的已有名称重名、
+// 也不与被断言的表达式 x 中出现的标识符重名的标识符
+func freeName(scope *types.Scope, pos token.Pos, base string, x ast.Expr, conflicts func(types.Object) bool) string {
+	used := map[string]bool{}
+	ast.Inspect(x, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			used[id.Name] = true
+		}
+		return true
+	})
+	name := base
+	for i := 2; ; i++ {
+		if _, obj := scope.LookupParent(name, pos); !used[name] && (obj == nil || !conflicts(obj)) {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// assertionVarName 根据断言的类型取变量名：类型名首字母小写，如 Cat 为 cat，*MyError 为 myError
+func assertionVarName(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		r := []rune(named.Obj().Name())
+		r[0] = unicode.ToLower(r[0])
+		if name := string(r); token.IsIdentifier(name) && !token.IsKeyword(name) && types.Universe.Lookup(name) == nil {
+			return name
+		}
+	}
+	return "v"
+}
+
+// assertionReturn 返回断言失败时使用的 return 语句：没有返回值时是 return，
+// 最后一个返回值是 error 时返回 fmt.Errorf 说明实际类型，其他返回值使用零值。
+// 文件没有导入 fmt 时同时返回添加导入的修改
+func assertionReturn(pass *analysis.Pass, file *ast.File, sig *types.Signature, ta *ast.TypeAssertExpr) (string, *analysis.TextEdit, bool) {
+	n := sig.Results().Len()
+	if n == 0 {
+		return "return", nil, true
+	}
+	qual := fileQualifier(pass, file)
+	var values []string
+	var edit *analysis.TextEdit
+	for i := 0; i < n; i++ {
+		t := sig.Results().At(i).Type()
+		if i == n-1 && types.Identical(t, types.Universe.Lookup("error").Type()) {
+			fmtName, e := importStd(pass, file, "fmt")
+			edit = e
+			values = append(values, fmtName+".Errorf(\"unexpected type %T, want "+types.TypeString(pass.TypesInfo.TypeOf(ta.Type), qual)+"\", "+types.ExprString(ta.X)+")")
+			continue
+		}
+		v, ok := zeroValue(t, qual)
+		if !ok {
+			return "", nil, false
+		}
+		values = append(values, v)
+	}
+	return "return " + strings.Join(values, ", "), edit, true
+}
+
+// zeroValue 返回类型 t 的零值的写法
+func zeroValue(t types.Type, qual types.Qualifier) (string, bool) {
+	if _, ok := t.(*types.TypeParam); ok {
+		return "*new(" + types.TypeString(t, qual) + ")", true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false", true
+		case u.Info()&types.IsNumeric != 0:
+			return "0", true
+		case u.Info()&types.IsString != 0:
+			return `""`, true
+		case u.Kind() == types.UnsafePointer:
+			return "nil", true
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil", true
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, qual) + "{}", true
+	}
+	return "", false
+}
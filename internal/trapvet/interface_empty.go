@@ -0,0 +1,69 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var interfaceEmpty = newAnalyzer("interface_empty",
+	"检查先后保存多种具体类型的空接口局部变量",
+	runInterfaceEmpty)
+
+func runInterfaceEmpty(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		// 每个 var x interface{} 局部变量被赋予的具体类型，按出现顺序去重
+		kinds := make(map[*types.Var][]string)
+		var order []*ast.Ident
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				for _, name := range n.Names {
+					if v, ok := pass.TypesInfo.Defs[name].(*types.Var); ok && isEmptyInterface(v.Type()) && n.Type != nil {
+						kinds[v] = nil
+						order = append(order, name)
+					}
+				}
+			case *ast.AssignStmt:
+				if n.Tok != token.ASSIGN || len(n.Lhs) != len(n.Rhs) {
+					return true
+				}
+				for i, lhs := range n.Lhs {
+					v := localVar(pass, lhs)
+					if _, ok := kinds[v]; !ok || v == nil {
+						continue
+					}
+					t := pass.TypesInfo.TypeOf(n.Rhs[i])
+					if t == nil || types.IsInterface(t) {
+						continue
+					}
+					name := typeString(pass, types.Default(t))
+					if !slices.Contains(kinds[v], name) {
+						kinds[v] = append(kinds[v], name)
+					}
+				}
+			}
+			return true
+		})
+		for _, name := range order {
+			v := pass.TypesInfo.Defs[name].(*types.Var)
+			if len(kinds[v]) >= 2 {
+				report(pass, "interface_empty", name, nil,
+					"局部变量 %s 是空接口，先后保存了 %s，取值时只能依靠类型断言，编译器无法检查；使用具体类型、泛型或为每种类型定义单独的变量",
+					v.Name(), strings.Join(kinds[v], i18n.T("、")))
+			}
+		}
+	})
+	return nil, nil
+}
+
+func isEmptyInterface(t types.Type) bool {
+	iface, ok := t.Underlying().(*types.Interface)
+	return ok && iface.Empty()
+}
@@ -0,0 +1,363 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var interfaceNil = newAnalyzer("interface_nil",
+	"检查以 error 等接口类型返回的 nil 指针：一直是 nil 的指针变量、(*T)(nil)，以及只在部分分支中赋值的指针变量",
+	runInterfaceNil)
+
+func runInterfaceNil(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		sig := fn.Type().(*types.Signature)
+		if !hasInterfaceResult(sig) {
+			return
+		}
+		checkNilConversionReturns(pass, sig, decl.Body)
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			var list []ast.Stmt
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.BlockStmt:
+				list = n.List
+			case *ast.CaseClause:
+				list = n.Body
+			case *ast.CommClause:
+				list = n.Body
+			}
+			for i, stmt := range list {
+				for _, v := range typedNilDecls(pass, stmt) {
+					t := typedNilReturn{pass: pass, sig: sig, body: decl.Body, decl: stmt, v: v}
+					t.check(list[i+1:], stillNil)
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// hasInterfaceResult 报告函数是否有接口类型的返回值
+func hasInterfaceResult(sig *types.Signature) bool {
+	for i := 0; i < sig.Results().Len(); i++ {
+		if types.IsInterface(sig.Results().At(i).Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// typedNilDecls 返回语句声明的值为 nil 的指针变量：var p *T、var p *T = nil、p := (*T)(nil)
+func typedNilDecls(pass *analysis.Pass, stmt ast.Stmt) []*types.Var {
+	var names, values []ast.Expr
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			return nil
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Values) != 0 && len(vs.Values) != len(vs.Names) {
+				continue
+			}
+			for i, name := range vs.Names {
+				names = append(names, name)
+				if len(vs.Values) == 0 {
+					values = append(values, nil)
+				} else {
+					values = append(values, vs.Values[i])
+				}
+			}
+		}
+	case *ast.AssignStmt:
+		if s.Tok != token.DEFINE || len(s.Lhs) != len(s.Rhs) {
+			return nil
+		}
+		names, values = s.Lhs, s.Rhs
+	}
+	var vars []*types.Var
+	for i, name := range names {
+		if values[i] != nil && !pass.TypesInfo.Types[values[i]].IsNil() && !isNilConversion(pass, values[i]) {
+			continue
+		}
+		v := localVar(pass, name)
+		if v == nil {
+			continue
+		}
+		if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+			vars = append(vars, v)
+		}
+	}
+	return vars
+}
+
+// isNilConversion 报告 e 是否是 (*T)(nil) 形式的类型转换
+func isNilConversion(pass *analysis.Pass, e ast.Expr) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !pass.TypesInfo.Types[call.Fun].IsType() {
+		return false
+	}
+	_, ok = pass.TypesInfo.TypeOf(call).Underlying().(*types.Pointer)
+	return ok && pass.TypesInfo.Types[call.Args[0]].IsNil()
+}
+
+// checkNilConversionReturns 报告直接把 (*T)(nil) 作为接口返回的 return 语句
+func checkNilConversionReturns(pass *analysis.Pass, sig *types.Signature, body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != sig.Results().Len() {
+				return true
+			}
+			for i, r := range n.Results {
+				res := sig.Results().At(i).Type()
+				if types.IsInterface(res) && isNilConversion(pass, r) {
+					report(pass, "interface_nil", r, returnNilFix(r),
+						"%s 作为 %s 返回后接口不是 nil：接口里保存了类型 %s 和一个 nil 值，调用方的 != nil 判断为真；没有值时直接返回 nil",
+						types.ExprString(r), typeString(pass, res), typeString(pass, pass.TypesInfo.TypeOf(r)))
+				}
+			}
+		}
+		return true
+	})
+}
+
+// nilState 是指针变量在某个位置上的状态
+type nilState int
+
+const (
+	stillNil nilState = iota // 一直是 nil
+	maybeNil                 // 只在部分路径上被赋值
+	notNil                   // 在所有路径上都被赋值或检查过 nil，不再跟踪
+	returned                 // 所有路径都已经返回
+)
+
+// mergeNil 合并两条路径汇合后的状态
+func mergeNil(a, b nilState) nilState {
+	switch {
+	case a == returned:
+		return b
+	case b == returned, a == b:
+		return a
+	}
+	return maybeNil
+}
+
+// typedNilReturn 沿着语句查找把 nil 指针变量 v 作为接口返回的 return 语句
+type typedNilReturn struct {
+	pass *analysis.Pass
+	sig  *types.Signature
+	body *ast.BlockStmt // 函数体
+	decl ast.Stmt       // 声明 v 的语句
+	v    *types.Var
+}
+
+// check 按顺序检查 stmts，st 是执行 stmts 之前 v 的状态，返回执行之后的状态
+func (t typedNilReturn) check(stmts []ast.Stmt, st nilState) nilState {
+	for _, stmt := range stmts {
+		if st == notNil || st == returned {
+			return st
+		}
+		st = t.stmt(stmt, st)
+	}
+	return st
+}
+
+func (t typedNilReturn) stmt(stmt ast.Stmt, st nilState) nilState {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt:
+		t.checkReturn(s, st)
+		return returned
+	case *ast.BlockStmt:
+		return t.check(s.List, st)
+	case *ast.LabeledStmt:
+		return t.stmt(s.Stmt, st)
+	case *ast.IfStmt:
+		if guardsNil(t.pass, s, t.v) || s.Init != nil && assigns(t.pass, s.Init, t.v) {
+			return notNil
+		}
+		els := st
+		if s.Else != nil {
+			els = t.stmt(s.Else, st)
+		}
+		return mergeNil(t.check(s.Body.List, st), els)
+	case *ast.SwitchStmt:
+		if guardsNil(t.pass, s, t.v) {
+			return notNil
+		}
+		return t.clauses(s, st)
+	case *ast.TypeSwitchStmt, *ast.SelectStmt:
+		return t.clauses(s, st)
+	case *ast.ForStmt, *ast.RangeStmt:
+		// 循环体可能一次也不执行；循环中被赋值时，后面的迭代里 v 不一定是 nil
+		in := st
+		if assigns(t.pass, s, t.v) {
+			in = maybeNil
+		}
+		var body *ast.BlockStmt
+		if f, ok := s.(*ast.ForStmt); ok {
+			body = f.Body
+		} else {
+			body = s.(*ast.RangeStmt).Body
+		}
+		if out := t.check(body.List, in); out != returned {
+			return mergeNil(st, out)
+		}
+		return st
+	}
+	if assigns(t.pass, stmt, t.v) {
+		return notNil
+	}
+	return st
+}
+
+// clauses 检查 switch、select 的各个分支并合并结果，switch 没有 default 分支时也可能一个分支都不执行
+func (t typedNilReturn) clauses(stmt ast.Stmt, st nilState) nilState {
+	var body *ast.BlockStmt
+	hasDefault := false
+	switch s := stmt.(type) {
+	case *ast.SwitchStmt:
+		if s.Init != nil && assigns(t.pass, s.Init, t.v) {
+			return notNil
+		}
+		body = s.Body
+	case *ast.TypeSwitchStmt:
+		body = s.Body
+	case *ast.SelectStmt:
+		body, hasDefault = s.Body, true // select 一定会执行某个分支
+	}
+	out := returned
+	for _, c := range body.List {
+		switch c := c.(type) {
+		case *ast.CaseClause:
+			if c.List == nil {
+				hasDefault = true
+			}
+			out = mergeNil(out, t.check(c.Body, st))
+		case *ast.CommClause:
+			if c.Comm != nil && assigns(t.pass, c.Comm, t.v) {
+				out = mergeNil(out, notNil)
+				continue
+			}
+			out = mergeNil(out, t.check(c.Body, st))
+		}
+	}
+	if !hasDefault {
+		out = mergeNil(out, st)
+	}
+	return out
+}
+
+// checkReturn 报告 return 语句中把 v 作为接口返回的结果
+func (t typedNilReturn) checkReturn(ret *ast.ReturnStmt, st nilState) {
+	if len(ret.Results) != t.sig.Results().Len() {
+		return
+	}
+	for i, r := range ret.Results {
+		res := t.sig.Results().At(i).Type()
+		if !types.IsInterface(res) || localVar(t.pass, r) != t.v {
+			continue
+		}
+		if st == stillNil {
+			report(t.pass, "interface_nil", r, t.returnNilFix(r),
+				"%s 在这里一直是 nil 指针，作为 %s 返回后接口不是 nil：接口里保存了类型 %s 和一个 nil 值，调用方的 != nil 判断为真；没有值时直接返回 nil",
+				t.v.Name(), typeString(t.pass, res), typeString(t.pass, t.v.Type()))
+		} else {
+			report(t.pass, "interface_nil", r, nil,
+				"%s 只在部分分支中被赋值，其他路径上它还是 nil 指针，作为 %s 返回后接口不是 nil：接口里保存了类型 %s 和一个 nil 值，调用方的 != nil 判断为真；在没有值的分支直接返回 nil，或把 %s 声明为 %s",
+				t.v.Name(), typeString(t.pass, res), typeString(t.pass, t.v.Type()), t.v.Name(), typeString(t.pass, res))
+		}
+	}
+}
+
+// returnNilFix 返回把返回值 r 改为 nil 的建议修复。r 是 v 唯一的使用时一起删除 v 的声明，
+// 否则 v 会变成未使用的变量；声明语句还声明了其他变量、或与其他代码在同一行时不提供修复
+func (t typedNilReturn) returnNilFix(r ast.Expr) []analysis.SuggestedFix {
+	uses := 0
+	ast.Inspect(t.body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && t.pass.TypesInfo.Uses[id] == t.v {
+			uses++
+		}
+		return true
+	})
+	fixes := returnNilFix(r)
+	if uses > 1 {
+		return fixes
+	}
+	if len(typedNilDecls(t.pass, t.decl)) != 1 || declaredNames(t.decl) != 1 || onlyImportUse(t.pass, t.decl) {
+		return nil
+	}
+	tf := t.pass.Fset.File(t.decl.Pos())
+	line := tf.Line(t.decl.Pos())
+	end := tf.Line(t.decl.End())
+	if !startsLine(t.pass, t.decl.Pos()) || end >= tf.LineCount() || tf.Offset(tf.LineStart(end+1))-1 != tf.Offset(t.decl.End()) {
+		return nil
+	}
+	fixes[0].TextEdits = append(fixes[0].TextEdits, analysis.TextEdit{Pos: tf.LineStart(line), End: tf.LineStart(end + 1)})
+	return fixes
+}
+
+// onlyImportUse 报告 stmt 中是否有某个导入的包在所在文件中唯一的使用，删除 stmt 后这个导入就没有用了
+func onlyImportUse(pass *analysis.Pass, stmt ast.Stmt) bool {
+	uses := make(map[*types.PkgName]int)
+	ast.Inspect(fileOf(pass, stmt.Pos()), func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if pkg, ok := pass.TypesInfo.Uses[id].(*types.PkgName); ok {
+				uses[pkg]++
+			}
+		}
+		return true
+	})
+	only := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if pkg, ok := pass.TypesInfo.Uses[id].(*types.PkgName); ok && uses[pkg] == 1 {
+				only = true
+			}
+		}
+		return !only
+	})
+	return only
+}
+
+// declaredNames 返回 var 或 := 语句声明的名字个数
+func declaredNames(stmt ast.Stmt) int {
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		n := 0
+		for _, spec := range s.Decl.(*ast.GenDecl).Specs {
+			n += len(spec.(*ast.ValueSpec).Names)
+		}
+		return n
+	case *ast.AssignStmt:
+		return len(s.Lhs)
+	}
+	return 0
+}
+
+// returnNilFix 返回把返回值 r 改为 nil 的建议修复
+func returnNilFix(r ast.Expr) []analysis.SuggestedFix {
+	return []analysis.SuggestedFix{{
+		Message: i18n.T("直接返回 nil"),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     r.Pos(),
+			End:     r.End(),
+			NewText: []byte("nil"),
+		}},
+	}}
+}
@@ -0,0 +1,93 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var interfaceReceiver = newAnalyzer("interface_receiver",
+	"检查把结构体的值赋给接口，而接口方法的值接收者实现会修改接收者，修改通过接口调用后丢失",
+	runInterfaceReceiver)
+
+func runInterfaceReceiver(pass *analysis.Pass) (any, error) {
+	// 本包中修改了值接收者字段的方法
+	mutating := make(map[*types.Func]bool)
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		if _, field := valueReceiverMutation(pass, decl); field != nil {
+			if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+				mutating[fn] = true
+			}
+		}
+	})
+	if len(mutating) == 0 {
+		return nil, nil
+	}
+
+	check := func(e ast.Expr, target types.Type) {
+		iface, ok := target.Underlying().(*types.Interface)
+		if !ok || e == nil {
+			return
+		}
+		t := pass.TypesInfo.TypeOf(e)
+		if t == nil || types.IsInterface(t) {
+			return
+		}
+		if _, ok := t.(*types.Named); !ok {
+			return
+		}
+		for i := 0; i < iface.NumMethods(); i++ {
+			obj, _, _ := types.LookupFieldOrMethod(t, false, pass.Pkg, iface.Method(i).Name())
+			if fn, ok := obj.(*types.Func); ok && mutating[fn] {
+				report(pass, "interface_receiver", e, nil,
+					"%s 的值被赋给接口 %s，通过接口调用的 %s 是值接收者方法，修改的是接口中保存的副本；赋值时使用指针 &%s，并把 %s 改为指针接收者",
+					typeString(pass, t), typeString(pass, target), fn.Name(), types.ExprString(e), fn.Name())
+				return
+			}
+		}
+	}
+
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		sig, _ := pass.TypesInfo.Defs[decl.Name].Type().(*types.Signature)
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				// 函数字面量的 return 对应它自己的结果类型，简单起见不检查函数字面量
+				return false
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i, lhs := range n.Lhs {
+						if t := pass.TypesInfo.TypeOf(lhs); t != nil {
+							check(n.Rhs[i], t)
+						}
+					}
+				}
+			case *ast.ValueSpec:
+				if n.Type != nil {
+					for _, v := range n.Values {
+						check(v, pass.TypesInfo.TypeOf(n.Type))
+					}
+				}
+			case *ast.ReturnStmt:
+				if sig != nil && sig.Results().Len() == len(n.Results) {
+					for i, r := range n.Results {
+						check(r, sig.Results().At(i).Type())
+					}
+				}
+			case *ast.CallExpr:
+				fsig, ok := pass.TypesInfo.TypeOf(n.Fun).(*types.Signature)
+				if !ok {
+					return true
+				}
+				for i, arg := range n.Args {
+					if i < fsig.Params().Len() && !(fsig.Variadic() && i >= fsig.Params().Len()-1) {
+						check(arg, fsig.Params().At(i).Type())
+					}
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
@@ -0,0 +1,140 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var mapConcurrent = newAnalyzer("map_concurrent",
+	"检查在 goroutine 中写入、同时在其他 goroutine 中读写且没有加锁的局部 map",
+	runMapConcurrent)
+
+// mapAccess 是一次对 map 的访问
+type mapAccess struct {
+	node  ast.Node
+	write bool
+	g     *ast.GoStmt // 访问所在的 goroutine，nil 表示函数本身
+	loop  bool        // goroutine 在循环中启动，会有多个实例
+}
+
+func runMapConcurrent(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		// 函数中有任何加锁都认为访问可能受保护
+		if callsLock(decl.Body) {
+			return
+		}
+		accesses := make(map[*types.Var][]mapAccess)
+		var vars []*types.Var
+		var syncs []token.Pos
+		inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+			if isWaitPoint(n) && !inGoroutine(stack) {
+				syncs = append(syncs, n.Pos())
+			}
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			v := localVar(pass, id)
+			if v == nil || pass.TypesInfo.Uses[id] == nil {
+				return true
+			}
+			if _, ok := v.Type().Underlying().(*types.Map); !ok {
+				return true
+			}
+			a := mapAccess{node: id, write: isMapWrite(pass, id, stack)}
+			for i := len(stack) - 1; i >= 2; i-- {
+				if lit, ok := stack[i].(*ast.FuncLit); ok && goroutineLit(lit, stack[i-1], stack[i-2]) {
+					a.g = stack[i-2].(*ast.GoStmt)
+					a.loop = inLoop(stack[:i-2])
+					break
+				}
+			}
+			if accesses[v] == nil {
+				vars = append(vars, v)
+			}
+			accesses[v] = append(accesses[v], a)
+			return true
+		})
+
+		for _, v := range vars {
+			if w := racyMapWrite(accesses[v], syncs); w != nil {
+				report(pass, "map_concurrent", w.node, nil,
+					"map %s 在 goroutine 中写入，同时在其他 goroutine 中读写，没有加锁，运行时会 fatal error: concurrent map read and map write；用 sync.Mutex 保护或改用 sync.Map",
+					v.Name())
+			}
+		}
+	})
+	return nil, nil
+}
+
+// racyMapWrite 返回与其他 goroutine 并发的第一次写入。
+// syncs 是函数本身（不在 goroutine 中）等待的位置：Wait 调用和通道接收
+func racyMapWrite(accesses []mapAccess, syncs []token.Pos) *mapAccess {
+	for i, w := range accesses {
+		if !w.write || w.g == nil {
+			continue
+		}
+		if w.loop {
+			return &accesses[i]
+		}
+		for _, o := range accesses {
+			if o.g == w.g {
+				continue
+			}
+			if o.g != nil || concurrentWith(w.g, o.node.Pos(), syncs) {
+				return &accesses[i]
+			}
+		}
+	}
+	return nil
+}
+
+// concurrentWith 报告函数本身在 pos 处的访问是否可能与 goroutine g 并发：
+// 访问在 go 语句之后，且两者之间没有等待
+func concurrentWith(g *ast.GoStmt, pos token.Pos, syncs []token.Pos) bool {
+	if pos < g.End() {
+		return false
+	}
+	for _, s := range syncs {
+		if g.End() < s && s < pos {
+			return false
+		}
+	}
+	return true
+}
+
+// inGoroutine 报告 stack 中是否有 go 语句
+func inGoroutine(stack []ast.Node) bool {
+	for _, n := range stack {
+		if _, ok := n.(*ast.GoStmt); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isMapWrite 报告对 map 变量 id 的使用是否是写入：m[k] = v、m[k]++、delete(m, k)
+func isMapWrite(pass *analysis.Pass, id *ast.Ident, stack []ast.Node) bool {
+	parent := stack[len(stack)-1]
+	if call, ok := parent.(*ast.CallExpr); ok {
+		return isBuiltin(pass, call, "delete") && call.Args[0] == id
+	}
+	index, ok := parent.(*ast.IndexExpr)
+	if !ok || index.X != id || len(stack) < 2 {
+		return false
+	}
+	switch s := stack[len(stack)-2].(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range s.Lhs {
+			if lhs == index {
+				return true
+			}
+		}
+	case *ast.IncDecStmt:
+		return s.X == index
+	}
+	return false
+}
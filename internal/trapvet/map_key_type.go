@@ -0,0 +1,52 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var mapKeyType = newAnalyzer("map_key_type",
+	"检查以接口为键的 map 中使用了不可比较的值作为键",
+	runMapKeyType)
+
+// 键类型本身不可比较时编译器已经报错，只有键类型是接口、动态类型不可比较时才会在运行时 panic
+func runMapKeyType(pass *analysis.Pass) (any, error) {
+	check := func(m, key ast.Expr) {
+		mt, ok := pass.TypesInfo.TypeOf(m).Underlying().(*types.Map)
+		if !ok || !types.IsInterface(mt.Key()) {
+			return
+		}
+		kt := pass.TypesInfo.TypeOf(key)
+		if kt == nil || types.IsInterface(kt) || types.Comparable(kt) {
+			return
+		}
+		report(pass, "map_key_type", key, nil,
+			"%s 类型的值不可比较，用作 %s 的键会在运行时 panic: hash of unhashable type；把键转换成字符串等可比较的类型",
+			typeString(pass, kt), typeString(pass, mt))
+	}
+
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.IndexExpr:
+				check(n.X, n.Index)
+			case *ast.CallExpr:
+				if isBuiltin(pass, n, "delete") && len(n.Args) == 2 {
+					check(n.Args[0], n.Args[1])
+				}
+			case *ast.CompositeLit:
+				if _, ok := pass.TypesInfo.TypeOf(n).Underlying().(*types.Map); ok {
+					for _, elt := range n.Elts {
+						if kv, ok := elt.(*ast.KeyValueExpr); ok {
+							check(n, kv.Key)
+						}
+					}
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
@@ -0,0 +1,328 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var mapNilWrite = newAnalyzer("map_nil_write",
+	"检查向只可能是 nil 的 map 写入：声明时没有 make 的变量、零值结构体的 map 字段、每个调用方都传入 nil 的参数",
+	runMapNilWrite)
+
+// nilMap 是一个只可能是 nil 的 map：局部变量或参数 v 本身，或者结构体变量 v 的字段 field
+type nilMap struct {
+	v     *types.Var
+	field *types.Var
+	decl  *ast.GenDecl   // 声明 m 的 var 语句，用于建议修复；字段和参数为 nil
+	spec  *ast.ValueSpec // decl 中只声明了 m 的 var m map[K]V
+	args  []ast.Expr     // 参数在各个调用方传入的 nil，用于建议修复
+}
+
+func (m nilMap) String() string {
+	if m.field != nil {
+		return m.v.Name() + "." + m.field.Name()
+	}
+	return m.v.Name()
+}
+
+func (m nilMap) mapType() types.Type {
+	if m.field != nil {
+		return m.field.Type()
+	}
+	return m.v.Type()
+}
+
+func runMapNilWrite(pass *analysis.Pass) (any, error) {
+	nilParams := nilMapParams(pass)
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+			for _, m := range nilParams[fn] {
+				checkNilMapWrite(pass, m, decl.Body.List)
+			}
+		}
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			var list []ast.Stmt
+			switch n := n.(type) {
+			case *ast.BlockStmt:
+				list = n.List
+			case *ast.CaseClause:
+				list = n.Body
+			case *ast.CommClause:
+				list = n.Body
+			default:
+				return true
+			}
+			for i, stmt := range list {
+				for _, m := range nilMapDecls(pass, stmt) {
+					checkNilMapWrite(pass, m, list[i+1:])
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// nilMapDecls 返回语句声明的 nil map：var m map[K]V，以及 var s T、s := T{...} 中没有赋值的 map 字段
+func nilMapDecls(pass *analysis.Pass, stmt ast.Stmt) []nilMap {
+	var maps []nilMap
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			return nil
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Values) > 0 {
+				continue
+			}
+			for _, name := range vs.Names {
+				v, ok := pass.TypesInfo.Defs[name].(*types.Var)
+				if !ok {
+					continue
+				}
+				if _, ok := v.Type().Underlying().(*types.Map); ok {
+					m := nilMap{v: v}
+					if len(vs.Names) == 1 {
+						m.decl, m.spec = gen, vs
+					}
+					maps = append(maps, m)
+				}
+				maps = append(maps, nilMapFields(v, nil)...)
+			}
+		}
+	case *ast.AssignStmt:
+		if s.Tok != token.DEFINE || len(s.Lhs) != len(s.Rhs) {
+			return nil
+		}
+		for i, lhs := range s.Lhs {
+			lit, ok := ast.Unparen(s.Rhs[i]).(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			if v := localVar(pass, lhs); v != nil {
+				maps = append(maps, nilMapFields(v, lit)...)
+			}
+		}
+	}
+	return maps
+}
+
+// nilMapFields 返回结构体变量 v 中没有被复合字面量 lit 赋值的 map 字段，lit 为 nil 表示零值
+func nilMapFields(v *types.Var, lit *ast.CompositeLit) []nilMap {
+	st, ok := v.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	set := make(map[string]bool)
+	if lit != nil {
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				// 按位置赋值的字面量给每个字段都赋了值
+				return nil
+			}
+			if id, ok := kv.Key.(*ast.Ident); ok {
+				set[id.Name] = true
+			}
+		}
+	}
+	var maps []nilMap
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if _, ok := f.Type().Underlying().(*types.Map); ok && !set[f.Name()] {
+			maps = append(maps, nilMap{v: v, field: f})
+		}
+	}
+	return maps
+}
+
+// nilMapParams 找出包中未导出函数的 map 参数，这些函数只被直接调用，且每个调用方都为该参数传入 nil。
+// 导出的函数可能被其他包以非 nil 的 map 调用，不做检查
+func nilMapParams(pass *analysis.Pass) map[*types.Func][]nilMap {
+	calls := make(map[*types.Func][]*ast.CallExpr)
+	escaped := make(map[*types.Func]bool)
+	for _, f := range pass.Files {
+		inspectStack(f, func(n ast.Node, stack []ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+			if !ok || fn.Pkg() != pass.Pkg || fn.Exported() || fn.Type().(*types.Signature).Recv() != nil {
+				return true
+			}
+			if call, ok := stack[len(stack)-1].(*ast.CallExpr); ok && call.Fun == id && !call.Ellipsis.IsValid() {
+				calls[fn] = append(calls[fn], call)
+			} else {
+				// 函数被当作值使用，无法知道所有调用方
+				escaped[fn] = true
+			}
+			return true
+		})
+	}
+
+	params := make(map[*types.Func][]nilMap)
+	for fn, cs := range calls {
+		if escaped[fn] {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		for i := 0; i < sig.Params().Len(); i++ {
+			p := sig.Params().At(i)
+			if _, ok := p.Type().Underlying().(*types.Map); !ok || sig.Variadic() && i == sig.Params().Len()-1 {
+				continue
+			}
+			m := nilMap{v: p}
+			for _, call := range cs {
+				if i >= len(call.Args) || !pass.TypesInfo.Types[call.Args[i]].IsNil() {
+					m.args = nil
+					break
+				}
+				m.args = append(m.args, call.Args[i])
+			}
+			if m.args != nil {
+				params[fn] = append(params[fn], m)
+			}
+		}
+	}
+	return params
+}
+
+// checkNilMapWrite 在 stmts 中按顺序查找对 m 的第一次写入，之前遇到赋值或 nil 检查时停止
+func checkNilMapWrite(pass *analysis.Pass, m nilMap, stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		if nilMapChanged(pass, stmt, m) || guardsNil(pass, stmt, m.v) {
+			return
+		}
+		if write, stmt := findNilMapWrite(pass, stmt, m); write != nil {
+			report(pass, "map_nil_write", write, nilMapFix(pass, m, stmt),
+				"%s 在这里只可能是 nil map，写入会 panic: assignment to entry in nil map；先用 make(%s) 创建 map",
+				m, typeString(pass, m.mapType()))
+			return
+		}
+	}
+}
+
+// nilMapChanged 报告 stmt 中是否可能让 m 不再是 nil：给变量或字段赋值、取地址、调用结构体变量的方法
+func nilMapChanged(pass *analysis.Pass, stmt ast.Stmt, m nilMap) bool {
+	if assigns(pass, stmt, m.v) {
+		return true
+	}
+	if m.field == nil {
+		return false
+	}
+	changed := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isField(pass, lhs, m) {
+					changed = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isField(pass, n.X, m) {
+				changed = true
+			}
+		case *ast.SelectorExpr:
+			if sel, ok := pass.TypesInfo.Selections[n]; ok && sel.Kind() == types.MethodVal && localVar(pass, n.X) == m.v {
+				changed = true
+			}
+		}
+		return !changed
+	})
+	return changed
+}
+
+// isField 报告 e 是否是 m 对应的 s.field
+func isField(pass *analysis.Pass, e ast.Expr, m nilMap) bool {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	return ok && localVar(pass, sel.X) == m.v && pass.TypesInfo.Uses[sel.Sel] == m.field
+}
+
+// findNilMapWrite 返回 stmt 中对 m 的第一次写入（m[k] = v、m[k]++）及所在的语句，函数字面量中的延迟执行不算
+func findNilMapWrite(pass *analysis.Pass, stmt ast.Stmt, m nilMap) (ast.Node, ast.Stmt) {
+	var write ast.Node
+	var in ast.Stmt
+	isMap := func(e ast.Expr) bool {
+		if m.field != nil {
+			return isField(pass, e, m)
+		}
+		return localVar(pass, e) == m.v
+	}
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if write != nil {
+			return false
+		}
+		var targets []ast.Expr
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			targets = n.Lhs
+		case *ast.IncDecStmt:
+			targets = []ast.Expr{n.X}
+		}
+		for _, t := range targets {
+			if index, ok := ast.Unparen(t).(*ast.IndexExpr); ok && isMap(index.X) {
+				write, in = index, n.(ast.Stmt)
+				return false
+			}
+		}
+		return true
+	})
+	return write, in
+}
+
+// nilMapFix 返回创建 map 的建议修复：var m map[K]V 声明改为 m := make(map[K]V)（在 var ( ... ) 中改为
+// m = make(map[K]V)），字段在写入前赋值，参数由调用方传入 make(map[K]V) 而不是 nil。
+// 在函数中给参数赋值只会修改副本，调用方看不到写入的内容
+func nilMapFix(pass *analysis.Pass, m nilMap, stmt ast.Stmt) []analysis.SuggestedFix {
+	typ := typeString(pass, m.mapType())
+	switch {
+	case m.decl != nil && !m.decl.Lparen.IsValid():
+		return []analysis.SuggestedFix{{
+			Message: i18n.Sprintf("声明时用 make(%s) 创建 map", typ),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     m.decl.Pos(),
+				End:     m.decl.End(),
+				NewText: []byte(m.String() + " := make(" + typ + ")"),
+			}},
+		}}
+	case m.decl != nil:
+		return []analysis.SuggestedFix{{
+			Message: i18n.Sprintf("声明时用 make(%s) 创建 map", typ),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     m.spec.Type.Pos(),
+				End:     m.spec.Type.End(),
+				NewText: []byte("= make(" + typ + ")"),
+			}},
+		}}
+	case m.args != nil:
+		var edits []analysis.TextEdit
+		for _, arg := range m.args {
+			edits = append(edits, analysis.TextEdit{Pos: arg.Pos(), End: arg.End(), NewText: []byte("make(" + typ + ")")})
+		}
+		return []analysis.SuggestedFix{{
+			Message:   i18n.Sprintf("调用方传入 make(%s) 而不是 nil", typ),
+			TextEdits: edits,
+		}}
+	}
+	indent := lineIndent(pass, stmt.Pos())
+	text := m.String() + " = make(" + typ + ")\n" + indent
+	return []analysis.SuggestedFix{{
+		Message: i18n.Sprintf("写入前用 make(%s) 创建 map", typ),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     stmt.Pos(),
+			End:     stmt.Pos(),
+			NewText: []byte(text),
+		}},
+	}}
+}
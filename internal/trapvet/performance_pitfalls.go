@@ -0,0 +1,451 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var performancePitfalls = newAnalyzer("performance_pitfalls",
+	"检查循环中用 += 拼接字符串、循环次数已知时向没有预分配容量的切片 append，以及 WriteString(fmt.Sprintf(...))",
+	runPerformancePitfalls)
+
+// performance_pitfalls 的子陷阱
+const (
+	perfStringConcat   = "performance_pitfalls/string_concat"   // 循环中用 += 拼接字符串
+	perfAppendPrealloc = "performance_pitfalls/append_prealloc" // 循环次数已知时没有预分配容量
+	perfSprintfWrite   = "performance_pitfalls/sprintf_write"   // WriteString(fmt.Sprintf(...))
+)
+
+// sprintfWriters 是 fmt 中生成字符串的函数和对应的写入 io.Writer 的函数
+var sprintfWriters = map[string]string{
+	"Sprintf":  "Fprintf",
+	"Sprint":   "Fprint",
+	"Sprintln": "Fprintln",
+}
+
+func runPerformancePitfalls(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		reported := make(map[*types.Var]bool)
+		inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				s, _ := concatOperand(pass, n)
+				if s == nil || reported[s] {
+					break
+				}
+				if loop := enclosingLoop(stack); loop != nil && s.Pos() < loop.Pos() {
+					reported[s] = true
+					report(pass, perfStringConcat, n, builderFix(pass, decl, s),
+						"循环中用 += 拼接字符串 %s，每次拼接都会分配新的字符串并复制已有的内容；改用 strings.Builder",
+						s.Name())
+				}
+			case *ast.CallExpr:
+				checkSprintfWrite(pass, n)
+			case *ast.BlockStmt:
+				for i, stmt := range n.List {
+					checkAppendPrealloc(pass, n.List[:i], stmt)
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// enclosingLoop 返回 stack 中同一个函数里最内层的循环，没有时返回 nil
+func enclosingLoop(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.FuncLit); ok {
+			return nil
+		}
+		if isLoop(stack[i]) {
+			return stack[i]
+		}
+	}
+	return nil
+}
+
+// concatOperand 对 s += x 和 s = s + x 形式的字符串拼接返回局部变量 s 和追加的 x，不是这种形式时返回 nil
+func concatOperand(pass *analysis.Pass, as *ast.AssignStmt) (*types.Var, ast.Expr) {
+	if len(as.Lhs) != 1 || len(as.Rhs) != 1 {
+		return nil, nil
+	}
+	s := localVar(pass, as.Lhs[0])
+	if s == nil {
+		return nil, nil
+	}
+	if b, ok := s.Type().Underlying().(*types.Basic); !ok || b.Info()&types.IsString == 0 {
+		return nil, nil
+	}
+	switch as.Tok {
+	case token.ADD_ASSIGN:
+		return s, as.Rhs[0]
+	case token.ASSIGN:
+		if bin, ok := ast.Unparen(as.Rhs[0]).(*ast.BinaryExpr); ok && bin.Op == token.ADD && localVar(pass, bin.X) == s {
+			return s, bin.Y
+		}
+	}
+	return nil, nil
+}
+
+// builderFix 返回把字符串变量 s 改为 strings.Builder 的建议修复：声明改为 var s strings.Builder，
+// 拼接改为 s.WriteString(x)，读取改为 s.String()。s 不是用 var s string 或 s := "" 声明的，
+// 或者有拼接以外的赋值、被取地址时返回 nil
+func builderFix(pass *analysis.Pass, decl *ast.FuncDecl, s *types.Var) []analysis.SuggestedFix {
+	if !types.Identical(s.Type(), types.Typ[types.String]) {
+		return nil
+	}
+	var edits []analysis.TextEdit
+	declared, ok := false, true
+	inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.DeclStmt:
+			gen, isGen := n.Decl.(*ast.GenDecl)
+			if !isGen || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+				break
+			}
+			vs := gen.Specs[0].(*ast.ValueSpec)
+			if len(vs.Names) != 1 || pass.TypesInfo.Defs[vs.Names[0]] != s {
+				break
+			}
+			if len(vs.Values) > 1 || len(vs.Values) == 1 && !isEmptyString(vs.Values[0]) {
+				ok = false
+			}
+			declared = true
+			edits = append(edits, analysis.TextEdit{Pos: n.Pos(), End: n.End(), NewText: []byte("var " + s.Name() + " strings.Builder")})
+			return false
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && n.Tok == token.DEFINE && pass.TypesInfo.Defs[identOf(n.Lhs[0])] == s {
+				ok = ok && isEmptyString(n.Rhs[0])
+				declared = true
+				edits = append(edits, analysis.TextEdit{Pos: n.Pos(), End: n.End(), NewText: []byte("var " + s.Name() + " strings.Builder")})
+				return false
+			}
+			v, x := concatOperand(pass, n)
+			if v != s {
+				break
+			}
+			if refersTo(pass, x, s) || !isString(pass, x) {
+				ok = false
+				return false
+			}
+			edits = append(edits, analysis.TextEdit{Pos: n.Pos(), End: n.End(), NewText: []byte(writeStringCall(pass, s.Name(), x))})
+			return false
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && localVar(pass, n.X) == s {
+				ok = false
+			}
+		case *ast.Ident:
+			if pass.TypesInfo.Uses[n] != s {
+				break
+			}
+			// 拼接以外的赋值
+			if as, isAssign := stack[len(stack)-1].(*ast.AssignStmt); isAssign {
+				for _, lhs := range as.Lhs {
+					if lhs == n {
+						ok = false
+					}
+				}
+			}
+			if inc, isInc := stack[len(stack)-1].(*ast.IncDecStmt); isInc && inc.X == n {
+				ok = false
+			}
+			edits = append(edits, analysis.TextEdit{Pos: n.Pos(), End: n.End(), NewText: []byte(s.Name() + ".String()")})
+		}
+		return ok
+	})
+	if !ok || !declared {
+		return nil
+	}
+	name, imp := importStd(pass, fileOf(pass, decl.Pos()), "strings")
+	if name != "strings" {
+		return nil
+	}
+	if imp != nil {
+		edits = append(edits, *imp)
+	}
+	return []analysis.SuggestedFix{{
+		Message:   i18n.T("改用 strings.Builder 拼接"),
+		TextEdits: edits,
+	}}
+}
+
+// isEmptyString 报告 e 是否是空字符串字面量
+func isEmptyString(e ast.Expr) bool {
+	lit, ok := ast.Unparen(e).(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING && (lit.Value == `""` || lit.Value == "``")
+}
+
+// isString 报告 e 的类型是否是 string 或无类型字符串常量
+func isString(pass *analysis.Pass, e ast.Expr) bool {
+	b, ok := pass.TypesInfo.TypeOf(e).(*types.Basic)
+	return ok && (b.Kind() == types.String || b.Kind() == types.UntypedString)
+}
+
+// writeStringCall 返回把 x 写入 builder 的调用：x 是 fmt.Sprintf(...) 时为 fmt.Fprintf(&builder, ...)，
+// 否则为 builder.WriteString(x)
+func writeStringCall(pass *analysis.Pass, builder string, x ast.Expr) string {
+	if call, fn := sprintfCall(pass, x); call != nil {
+		sel := call.Fun.(*ast.SelectorExpr)
+		return types.ExprString(sel.X) + "." + sprintfWriters[fn] + "(" + strings.Join(append([]string{"&" + builder}, callArgs(pass, call)...), ", ") + ")"
+	}
+	return builder + ".WriteString(" + sourceText(pass, x) + ")"
+}
+
+// sprintfCall 报告 e 是否是对 fmt.Sprintf、fmt.Sprint 或 fmt.Sprintln 的调用，是时返回调用和函数名
+func sprintfCall(pass *analysis.Pass, e ast.Expr) (*ast.CallExpr, string) {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return nil, ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ""
+	}
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || sprintfWriters[fn.Name()] == "" {
+		return nil, ""
+	}
+	return call, fn.Name()
+}
+
+// callArgs 返回调用的各个参数的源代码，最后一个参数带上 ...
+func callArgs(pass *analysis.Pass, call *ast.CallExpr) []string {
+	args := make([]string, 0, len(call.Args))
+	for _, arg := range call.Args {
+		args = append(args, sourceText(pass, arg))
+	}
+	if call.Ellipsis.IsValid() {
+		args[len(args)-1] += "..."
+	}
+	return args
+}
+
+// checkSprintfWrite 报告 w.WriteString(fmt.Sprintf(...))：Sprintf 先分配一个临时字符串再写入，
+// fmt.Fprintf 直接写入 w
+func checkSprintfWrite(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) != 1 ||
+		!isMethodCall(pass, call, "strings", "Builder", "WriteString") &&
+			!isMethodCall(pass, call, "bytes", "Buffer", "WriteString") &&
+			!isMethodCall(pass, call, "bufio", "Writer", "WriteString") {
+		return
+	}
+	inner, fn := sprintfCall(pass, call.Args[0])
+	if inner == nil {
+		return
+	}
+	w := ast.Unparen(call.Fun.(*ast.SelectorExpr).X)
+	writer := sourceText(pass, w)
+	if _, isPtr := pass.TypesInfo.TypeOf(w).(*types.Pointer); !isPtr {
+		switch w.(type) {
+		case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr:
+			writer = "&" + writer
+		default:
+			return // 不可寻址
+		}
+	}
+	fmtName := types.ExprString(inner.Fun.(*ast.SelectorExpr).X)
+	replacement := fmtName + "." + sprintfWriters[fn] + "(" + strings.Join(append([]string{writer}, callArgs(pass, inner)...), ", ") + ")"
+	report(pass, perfSprintfWrite, call, []analysis.SuggestedFix{{
+		Message: i18n.Sprintf("改为 %s", replacement),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			NewText: []byte(replacement),
+		}},
+	}},
+		"%s.%s 会先分配一个临时字符串，再复制到 %s 中；用 %s.%s(%s, ...) 直接写入",
+		fmtName, fn, types.ExprString(w), fmtName, sprintfWriters[fn], writer)
+}
+
+// checkAppendPrealloc 检查循环 stmt：循环次数是简单的表达式，循环体中无条件地向循环之前声明、
+// 没有指定容量的切片 append 一个元素。before 是同一个代码块中 stmt 之前的语句
+func checkAppendPrealloc(pass *analysis.Pass, before []ast.Stmt, stmt ast.Stmt) {
+	count := tripCount(pass, stmt)
+	if count == nil {
+		return
+	}
+	body := loopBody(stmt)
+	leaves := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt, *ast.ReturnStmt:
+			leaves = true
+		}
+		return !leaves
+	})
+	if leaves {
+		return
+	}
+	for _, s := range body.List {
+		as, ok := s.(*ast.AssignStmt)
+		if !ok || len(as.Lhs) != 1 || len(as.Rhs) != 1 {
+			continue
+		}
+		call, ok := ast.Unparen(as.Rhs[0]).(*ast.CallExpr)
+		if !ok || !isBuiltin(pass, call, "append") || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+			continue
+		}
+		v := localVar(pass, as.Lhs[0])
+		if v == nil || localVar(pass, call.Args[0]) != v {
+			continue
+		}
+		// 声明在同一个代码块中，声明之后到循环之前没有用到 v，也没有修改循环次数中的变量
+		for i := len(before) - 1; i >= 0; i-- {
+			elem, ok := uncappedDecl(pass, before[i], v)
+			if !ok {
+				if refersTo(pass, before[i], v) {
+					break
+				}
+				continue
+			}
+			if !availableAt(pass, count, before[i], before[i+1:]) {
+				break
+			}
+			text := types.ExprString(count)
+			replacement := v.Name() + " := make(" + elem + ", 0, " + text + ")"
+			report(pass, perfAppendPrealloc, as, []analysis.SuggestedFix{{
+				Message: i18n.T("用 make 预分配容量"),
+				TextEdits: []analysis.TextEdit{{
+					Pos:     before[i].Pos(),
+					End:     before[i].End(),
+					NewText: []byte(replacement),
+				}},
+			}},
+				"循环会 append %s 次，%s 声明时没有指定容量，append 会多次扩容并复制已有的元素；声明时用 %s 预分配容量",
+				text, v.Name(), replacement)
+			break
+		}
+	}
+}
+
+// tripCount 返回循环的次数：for i := 0; i < n; i++ 中的 n，range x 中的 len(x)，range n 中的 n。
+// 次数不是简单的表达式时返回 nil
+func tripCount(pass *analysis.Pass, stmt ast.Stmt) ast.Expr {
+	switch loop := stmt.(type) {
+	case *ast.ForStmt:
+		init, ok := loop.Init.(*ast.AssignStmt)
+		if !ok || len(init.Lhs) != 1 || len(init.Rhs) != 1 || init.Tok != token.DEFINE {
+			return nil
+		}
+		if lit, ok := init.Rhs[0].(*ast.BasicLit); !ok || lit.Value != "0" {
+			return nil
+		}
+		i := localVar(pass, init.Lhs[0])
+		cond, ok := loop.Cond.(*ast.BinaryExpr)
+		if !ok || cond.Op != token.LSS || localVar(pass, cond.X) != i {
+			return nil
+		}
+		post, ok := loop.Post.(*ast.IncDecStmt)
+		if !ok || post.Tok != token.INC || localVar(pass, post.X) != i || assigns(pass, loop.Body, i) {
+			return nil
+		}
+		if !simpleExpr(pass, cond.Y) {
+			return nil
+		}
+		return cond.Y
+	case *ast.RangeStmt:
+		if !simpleExpr(pass, loop.X) {
+			return nil
+		}
+		switch t := pass.TypesInfo.TypeOf(loop.X).Underlying().(type) {
+		case *types.Slice, *types.Map:
+			return &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{loop.X}}
+		case *types.Basic:
+			if t.Info()&types.IsInteger != 0 {
+				return loop.X
+			}
+		}
+	}
+	return nil
+}
+
+// simpleExpr 报告 e 是否是常量、变量、x.f 或 len(x) 这样求值没有副作用的简单表达式
+func simpleExpr(pass *analysis.Pass, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BasicLit, *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return simpleExpr(pass, e.X)
+	case *ast.CallExpr:
+		return isBuiltin(pass, e, "len") && len(e.Args) == 1 && simpleExpr(pass, e.Args[0])
+	}
+	return false
+}
+
+// uncappedDecl 报告 stmt 是否是没有指定容量的切片 v 的声明：var v []T、v := []T{}、v := make([]T, 0)，
+// 是时返回切片类型的源代码
+func uncappedDecl(pass *analysis.Pass, stmt ast.Stmt, v *types.Var) (string, bool) {
+	if _, ok := v.Type().(*types.Slice); !ok {
+		return "", false
+	}
+	var value ast.Expr
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		gen, ok := s.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+			return "", false
+		}
+		vs := gen.Specs[0].(*ast.ValueSpec)
+		if len(vs.Names) != 1 || pass.TypesInfo.Defs[vs.Names[0]] != v {
+			return "", false
+		}
+		if len(vs.Values) == 0 {
+			return sourceText(pass, vs.Type), vs.Type != nil
+		}
+		value = vs.Values[0]
+	case *ast.AssignStmt:
+		if s.Tok != token.DEFINE || len(s.Lhs) != 1 || len(s.Rhs) != 1 || pass.TypesInfo.Defs[identOf(s.Lhs[0])] != v {
+			return "", false
+		}
+		value = s.Rhs[0]
+	default:
+		return "", false
+	}
+	switch value := ast.Unparen(value).(type) {
+	case *ast.CompositeLit:
+		if len(value.Elts) == 0 && value.Type != nil {
+			return sourceText(pass, value.Type), true
+		}
+	case *ast.CallExpr:
+		if isBuiltin(pass, value, "make") && len(value.Args) == 2 {
+			if lit, ok := value.Args[1].(*ast.BasicLit); ok && lit.Value == "0" {
+				return sourceText(pass, value.Args[0]), true
+			}
+		}
+	}
+	return "", false
+}
+
+// availableAt 报告表达式 e 在声明语句 decl 处是否已经可以求值，并且在 decl 之后的 between 中没有被修改
+func availableAt(pass *analysis.Pass, e ast.Expr, decl ast.Stmt, between []ast.Stmt) bool {
+	ok := true
+	ast.Inspect(e, func(n ast.Node) bool {
+		id, isIdent := n.(*ast.Ident)
+		if !isIdent {
+			return ok
+		}
+		v, isVar := pass.TypesInfo.Uses[id].(*types.Var)
+		if !isVar || v.IsField() {
+			return ok
+		}
+		if v.Pos() >= decl.Pos() {
+			ok = false
+		}
+		for _, s := range between {
+			if assigns(pass, s, v) {
+				ok = false
+			}
+		}
+		return ok
+	})
+	return ok
+}
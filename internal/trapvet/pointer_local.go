@@ -0,0 +1,65 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// 返回局部变量的指针本身是安全的，逃逸分析会把变量分配到堆上。
+// 真正危险的是把地址转换成 uintptr 保存下来：GC 不把 uintptr 当作指针，变量可能被回收。
+var pointerLocal = newAnalyzer("pointer_local",
+	"检查把局部变量的地址转换成 uintptr 后返回或保存",
+	runPointerLocal)
+
+func runPointerLocal(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			var values []ast.Expr
+			switch n := n.(type) {
+			case *ast.ReturnStmt:
+				values = n.Results
+			case *ast.AssignStmt:
+				values = n.Rhs
+			case *ast.ValueSpec:
+				values = n.Values
+			}
+			for _, e := range values {
+				if v := uintptrOfLocal(pass, e); v != nil {
+					report(pass, "pointer_local", e, nil,
+						"局部变量 %s 的地址被转换成 uintptr 保存，GC 不会把 uintptr 当作指针，%s 可能被回收或移动；直接使用 &%s，逃逸分析会把它分配到堆上",
+						v.Name(), v.Name(), v.Name())
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// uintptrOfLocal 识别 uintptr(unsafe.Pointer(&x))，返回局部变量 x
+func uintptrOfLocal(pass *analysis.Pass, e ast.Expr) *types.Var {
+	inner := conversionArg(pass, e, types.Uintptr)
+	if inner == nil {
+		return nil
+	}
+	addr, ok := ast.Unparen(conversionArg(pass, inner, types.UnsafePointer)).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return nil
+	}
+	return localVar(pass, addr.X)
+}
+
+// conversionArg 如果 e 是转换为基本类型 kind 的类型转换，返回被转换的表达式
+func conversionArg(pass *analysis.Pass, e ast.Expr, kind types.BasicKind) ast.Expr {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !pass.TypesInfo.Types[call.Fun].IsType() {
+		return nil
+	}
+	if b, ok := pass.TypesInfo.TypeOf(call.Fun).(*types.Basic); !ok || b.Kind() != kind {
+		return nil
+	}
+	return call.Args[0]
+}
@@ -0,0 +1,97 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var pointerNil = newAnalyzer("pointer_nil",
+	"检查声明后没有赋值、也没有检查 nil 就解引用的指针变量",
+	runPointerNil)
+
+func runPointerNil(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			block, ok := n.(*ast.BlockStmt)
+			if !ok {
+				return true
+			}
+			for i, stmt := range block.List {
+				for _, v := range nilPointerDecls(pass, stmt) {
+					checkNilDeref(pass, v, block.List[i+1:])
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// nilPointerDecls 返回 var p *T 形式声明的、没有初始值的指针变量
+func nilPointerDecls(pass *analysis.Pass, stmt ast.Stmt) []*types.Var {
+	ds, ok := stmt.(*ast.DeclStmt)
+	if !ok {
+		return nil
+	}
+	gen, ok := ds.Decl.(*ast.GenDecl)
+	if !ok || gen.Tok != token.VAR {
+		return nil
+	}
+	var vars []*types.Var
+	for _, spec := range gen.Specs {
+		vs := spec.(*ast.ValueSpec)
+		if len(vs.Values) > 0 {
+			continue
+		}
+		for _, name := range vs.Names {
+			if v, ok := pass.TypesInfo.Defs[name].(*types.Var); ok {
+				if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+					vars = append(vars, v)
+				}
+			}
+		}
+	}
+	return vars
+}
+
+// checkNilDeref 在声明之后的语句中查找第一次解引用，之前遇到赋值或 nil 检查时停止
+func checkNilDeref(pass *analysis.Pass, v *types.Var, stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		if assigns(pass, stmt, v) || guardsNil(pass, stmt, v) {
+			return
+		}
+		if deref := findDeref(pass, stmt, v); deref != nil {
+			report(pass, "pointer_nil", deref, nil,
+				"指针 %s 声明后一直是 nil，这里解引用会 panic: invalid memory address or nil pointer dereference；先让它指向一个值，或在使用前检查 %s != nil",
+				v.Name(), v.Name())
+			return
+		}
+	}
+}
+
+// findDeref 返回语句中对 v 的第一次解引用（*v、v.字段），函数字面量中的延迟执行不算
+func findDeref(pass *analysis.Pass, stmt ast.Stmt, v *types.Var) ast.Node {
+	var deref ast.Node
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if deref != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.StarExpr:
+			if localVar(pass, n.X) == v {
+				deref = n
+			}
+		case *ast.SelectorExpr:
+			if sel, ok := pass.TypesInfo.Selections[n]; ok && sel.Kind() == types.FieldVal && localVar(pass, n.X) == v {
+				deref = n
+			}
+		}
+		return true
+	})
+	return deref
+}
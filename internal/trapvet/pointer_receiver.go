@@ -0,0 +1,91 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var pointerReceiver = newAnalyzer("pointer_receiver",
+	"检查修改接收者字段的值接收者方法，修改只作用于副本",
+	runPointerReceiver)
+
+func runPointerReceiver(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		recv, field := valueReceiverMutation(pass, decl)
+		if field == nil {
+			return
+		}
+		report(pass, "pointer_receiver", field, nil,
+			"%s 是值接收者方法，对 %s 的修改只作用于接收者的副本，调用方看不到；需要修改接收者时使用指针接收者 *%s",
+			decl.Name.Name, types.ExprString(field), typeString(pass, recv.Type()))
+	})
+	return nil, nil
+}
+
+// valueReceiverMutation 检查值接收者方法是否修改了接收者自身的字段，
+// 返回接收者和第一个被修改的字段；方法把接收者返回出去时不算（如 func (c Config) With(...) Config）
+func valueReceiverMutation(pass *analysis.Pass, decl *ast.FuncDecl) (*types.Var, ast.Expr) {
+	if decl.Recv == nil || len(decl.Recv.List) != 1 || len(decl.Recv.List[0].Names) != 1 {
+		return nil, nil
+	}
+	recv, ok := pass.TypesInfo.Defs[decl.Recv.List[0].Names[0]].(*types.Var)
+	if !ok {
+		return nil, nil
+	}
+	if _, ok := recv.Type().Underlying().(*types.Struct); !ok {
+		return nil, nil
+	}
+
+	var field ast.Expr
+	returned := false
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			for _, r := range n.Results {
+				if localVar(pass, r) == recv {
+					returned = true
+				}
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if field == nil && isReceiverField(pass, lhs, recv) {
+					field = lhs
+				}
+			}
+		case *ast.IncDecStmt:
+			if field == nil && isReceiverField(pass, n.X, recv) {
+				field = n.X
+			}
+		}
+		return true
+	})
+	if returned {
+		return nil, nil
+	}
+	return recv, field
+}
+
+// isReceiverField 报告 e 是否是 recv.a.b 形式、中间不经过指针的字段，
+// 经过指针、map 或切片的修改会作用到接收者之外的数据，不算修改副本
+func isReceiverField(pass *analysis.Pass, e ast.Expr, recv *types.Var) bool {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	for {
+		s, ok := pass.TypesInfo.Selections[sel]
+		if !ok || s.Kind() != types.FieldVal || s.Indirect() {
+			return false
+		}
+		switch x := ast.Unparen(sel.X).(type) {
+		case *ast.Ident:
+			return pass.TypesInfo.Uses[x] == recv
+		case *ast.SelectorExpr:
+			sel = x
+		default:
+			return false
+		}
+	}
+}
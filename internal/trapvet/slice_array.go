@@ -0,0 +1,107 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var sliceArray = newAnalyzer("slice_array",
+	"检查对子切片 append 后赋给另一个变量，append 可能覆盖原切片的元素",
+	runSliceArray)
+
+func runSliceArray(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		// 由 s[a:b] 定义、之后没有重新赋值的局部切片
+		subslices := make(map[*types.Var]*ast.SliceExpr)
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			as, ok := n.(*ast.AssignStmt)
+			if !ok || as.Tok != token.DEFINE || len(as.Lhs) != len(as.Rhs) {
+				return true
+			}
+			for i, lhs := range as.Lhs {
+				if se, ok := ast.Unparen(as.Rhs[i]).(*ast.SliceExpr); ok && !se.Slice3 && isSlice(pass, se.X) {
+					if v := localVar(pass, lhs); v != nil {
+						subslices[v] = se
+					}
+				}
+			}
+			return true
+		})
+
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			as, ok := n.(*ast.AssignStmt)
+			if !ok || len(as.Lhs) != 1 || len(as.Rhs) != 1 {
+				return true
+			}
+			call, ok := ast.Unparen(as.Rhs[0]).(*ast.CallExpr)
+			if !ok || !isBuiltin(pass, call, "append") || len(call.Args) < 2 {
+				return true
+			}
+			dst := localVar(pass, as.Lhs[0])
+			src := localVar(pass, call.Args[0])
+			se := subslices[src]
+			if dst == nil || src == nil || dst == src || se == nil || refersTo(pass, se.X, dst) {
+				return true
+			}
+			// 子切片之后又被重新赋值时无法确定它的来源
+			if reassigned(pass, decl.Body, src, as) {
+				return true
+			}
+
+			var fixes []analysis.SuggestedFix
+			if se.High != nil {
+				fixes = []analysis.SuggestedFix{{
+					Message: i18n.Sprintf("改为完整切片表达式 %s", limitedSlice(se)),
+					TextEdits: []analysis.TextEdit{{
+						Pos:     se.High.End(),
+						End:     se.High.End(),
+						NewText: []byte(":" + types.ExprString(se.High)),
+					}},
+				}}
+			}
+			report(pass, "slice_array", call, fixes,
+				"%s 是 %s 的子切片，容量足够时 append 会直接覆盖 %s 的元素，%s 与 %s 共享底层数组；用完整切片表达式 %s 限制容量，或先 copy 出独立的切片",
+				src.Name(), types.ExprString(se.X), types.ExprString(se.X), dst.Name(), types.ExprString(se.X), limitedSlice(se))
+			return true
+		})
+	})
+	return nil, nil
+}
+
+func isSlice(pass *analysis.Pass, e ast.Expr) bool {
+	_, ok := pass.TypesInfo.TypeOf(e).Underlying().(*types.Slice)
+	return ok
+}
+
+// reassigned 报告 v 在定义之外是否还被赋值过（不含 except 语句本身）
+func reassigned(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var, except ast.Node) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if as, ok := n.(*ast.AssignStmt); ok && as != except && as.Tok != token.DEFINE {
+			for _, lhs := range as.Lhs {
+				if localVar(pass, lhs) == v {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// limitedSlice 返回限制了容量的切片表达式，如 original[:2:2]
+func limitedSlice(se *ast.SliceExpr) string {
+	low, high := "", "len("+types.ExprString(se.X)+")"
+	if se.Low != nil {
+		low = types.ExprString(se.Low)
+	}
+	if se.High != nil {
+		high = types.ExprString(se.High)
+	}
+	return types.ExprString(se.X) + "[" + low + ":" + high + ":" + high + "]"
+}
@@ -0,0 +1,221 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var slicePointer = newAnalyzer("slice_pointer",
+	"检查 Go 1.22 之前保存循环变量的地址，以及 append 之后继续使用之前取得的切片元素地址",
+	runSlicePointer)
+
+// slice_pointer 的子陷阱
+const (
+	pointerLoopVar      = "slice_pointer/loop_var"      // 保存循环变量的地址
+	pointerStaleElement = "slice_pointer/stale_element" // append 之后使用旧的元素地址
+)
+
+func runSlicePointer(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		if v := fileVersion(pass, decl.Pos()); v != "" && version.Compare(v, "go1.22") < 0 {
+			checkLoopVarAddr(pass, decl.Body)
+		}
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			var list []ast.Stmt
+			switch n := n.(type) {
+			case *ast.BlockStmt:
+				list = n.List
+			case *ast.CaseClause:
+				list = n.Body
+			case *ast.CommClause:
+				list = n.Body
+			}
+			for i, stmt := range list {
+				checkStaleElement(pass, stmt, list[i+1:])
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// checkLoopVarAddr 报告循环体中保存到迭代之外的循环变量地址：作为 append 的参数、
+// 赋给循环外声明的变量或切片、map 的元素、发送到通道
+func checkLoopVarAddr(pass *analysis.Pass, body *ast.BlockStmt) {
+	inspectStack(body, func(n ast.Node, stack []ast.Node) bool {
+		u, ok := n.(*ast.UnaryExpr)
+		if !ok || u.Op != token.AND || len(stack) == 0 {
+			return true
+		}
+		v := localVar(pass, u.X)
+		if v == nil {
+			return true
+		}
+		var loop ast.Node
+		for i := len(stack) - 1; i >= 0 && loop == nil; i-- {
+			for _, lv := range loopVars(pass, stack[i]) {
+				if lv == v && u.Pos() >= loopBody(stack[i]).Pos() {
+					loop = stack[i]
+				}
+			}
+		}
+		if loop == nil || !outlivesIteration(pass, u, stack[len(stack)-1], loop) {
+			return true
+		}
+		report(pass, pointerLoopVar, u, nil,
+			"Go 1.22 之前循环变量 %s 由所有迭代共享，保存的 &%s 都指向同一个变量，循环结束后读到的都是最后的值；在循环体中先用 %s := %s 创建新的变量再取地址",
+			v.Name(), v.Name(), v.Name(), v.Name())
+		return true
+	})
+}
+
+// loopBody 返回 for 或 range 语句的循环体
+func loopBody(loop ast.Node) *ast.BlockStmt {
+	if f, ok := loop.(*ast.ForStmt); ok {
+		return f.Body
+	}
+	return loop.(*ast.RangeStmt).Body
+}
+
+// outlivesIteration 报告地址 u 是否被保存到本次迭代之外，parent 是 u 的父节点
+func outlivesIteration(pass *analysis.Pass, u *ast.UnaryExpr, parent ast.Node, loop ast.Node) bool {
+	switch p := parent.(type) {
+	case *ast.CallExpr:
+		return isBuiltin(pass, p, "append") && len(p.Args) > 0 && p.Args[0] != u
+	case *ast.SendStmt:
+		return p.Value == u
+	case *ast.AssignStmt:
+		if len(p.Lhs) != len(p.Rhs) {
+			return false
+		}
+		for i, rhs := range p.Rhs {
+			if rhs != u {
+				continue
+			}
+			switch lhs := ast.Unparen(p.Lhs[i]).(type) {
+			case *ast.IndexExpr, *ast.SelectorExpr, *ast.StarExpr:
+				return true
+			case *ast.Ident:
+				// 局部变量只看循环之前声明的，包级变量一定活得比迭代长
+				v, ok := pass.TypesInfo.ObjectOf(lhs).(*types.Var)
+				return ok && (v.Pos() < loop.Pos() || localVar(pass, lhs) == nil)
+			}
+		}
+	}
+	return false
+}
+
+// checkStaleElement 检查 stmt 中取得的切片元素地址 p := &s[i]：之后的语句中 s = append(s, ...) 可能让 s
+// 换到新的底层数组，再通过 p 读写的就是旧数组中的元素
+func checkStaleElement(pass *analysis.Pass, stmt ast.Stmt, rest []ast.Stmt) {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(as.Lhs) != len(as.Rhs) {
+		return
+	}
+	for i, rhs := range as.Rhs {
+		p := localVar(pass, as.Lhs[i])
+		s := elementAddrOf(pass, rhs)
+		if p == nil || s == nil {
+			continue
+		}
+		var grow ast.Node
+		for _, next := range rest {
+			if redefines(pass, next, p) {
+				break
+			}
+			if use := firstUse(pass, next, p); grow != nil && use != nil {
+				report(pass, pointerStaleElement, use, nil,
+					"%s 是 %s 中元素的地址，第 %d 行的 append 可能让 %s 换到新的底层数组，之后通过 %s 读写的是旧数组中的元素；在 append 之后重新取地址，或保存索引而不是元素的地址",
+					p.Name(), s.Name(), pass.Fset.Position(grow.Pos()).Line, s.Name(), p.Name())
+				break
+			}
+			if assigns(pass, next, p) {
+				break
+			}
+			if grow == nil && appendsTo(pass, next, s) {
+				grow = next
+			}
+		}
+	}
+}
+
+// redefines 报告 stmt 是否是不读取 v 的、给 v 重新赋值的赋值语句
+func redefines(pass *analysis.Pass, stmt ast.Stmt, v *types.Var) bool {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || as.Tok != token.ASSIGN && as.Tok != token.DEFINE {
+		return false
+	}
+	for _, rhs := range as.Rhs {
+		if refersTo(pass, rhs, v) {
+			return false
+		}
+	}
+	for _, lhs := range as.Lhs {
+		if localVar(pass, lhs) == v {
+			return true
+		}
+	}
+	return false
+}
+
+// firstUse 返回 node 中第一个引用变量 v 的标识符，没有时返回 nil
+func firstUse(pass *analysis.Pass, node ast.Node, v *types.Var) *ast.Ident {
+	var use *ast.Ident
+	ast.Inspect(node, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && use == nil && pass.TypesInfo.Uses[id] == v {
+			use = id
+		}
+		return use == nil
+	})
+	return use
+}
+
+// elementAddrOf 返回 &s[i] 或 &s[i].f 中的切片变量 s，e 不是这种形式时返回 nil
+func elementAddrOf(pass *analysis.Pass, e ast.Expr) *types.Var {
+	u, ok := ast.Unparen(e).(*ast.UnaryExpr)
+	if !ok || u.Op != token.AND {
+		return nil
+	}
+	x := ast.Unparen(u.X)
+	for {
+		sel, ok := x.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		if s, ok := pass.TypesInfo.Selections[sel]; !ok || s.Indirect() {
+			return nil
+		}
+		x = ast.Unparen(sel.X)
+	}
+	index, ok := x.(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
+	if _, ok := pass.TypesInfo.TypeOf(index.X).Underlying().(*types.Slice); !ok {
+		return nil
+	}
+	return localVar(pass, index.X)
+}
+
+// appendsTo 报告 node 中是否有 s = append(s, ...)
+func appendsTo(pass *analysis.Pass, node ast.Node, s *types.Var) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		as, ok := n.(*ast.AssignStmt)
+		if !ok || len(as.Lhs) != len(as.Rhs) {
+			return !found
+		}
+		for i, lhs := range as.Lhs {
+			call, ok := ast.Unparen(as.Rhs[i]).(*ast.CallExpr)
+			if ok && localVar(pass, lhs) == s && isBuiltin(pass, call, "append") && len(call.Args) > 0 && localVar(pass, call.Args[0]) == s {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,235 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var sliceRangeModify = newAnalyzer("slice_range_modify",
+	"检查遍历时修改切片或 map：修改后没有再读取的元素副本、向正在遍历的切片追加或原地删除元素、向正在遍历的 map 添加新键",
+	runSliceRangeModify)
+
+// slice_range_modify 的子陷阱
+const (
+	rangeValueCopy = "slice_range_modify/value_copy" // 修改 range 的元素副本
+	rangeAppend    = "slice_range_modify/append"     // 向正在遍历的切片追加元素
+	rangeDelete    = "slice_range_modify/delete"     // 从正在遍历的切片中原地删除元素
+	rangeMapInsert = "slice_range_modify/map_insert" // 向正在遍历的 map 添加新键
+)
+
+func runSliceRangeModify(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if r, ok := n.(*ast.RangeStmt); ok {
+				checkValueCopy(pass, r)
+				checkRangeAssign(pass, r)
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// checkValueCopy 报告对 range 元素副本的修改：修改之后在同一次迭代中没有再读取，修改就丢失了
+func checkValueCopy(pass *analysis.Pass, r *ast.RangeStmt) {
+	if r.Tok != token.DEFINE || r.Value == nil {
+		return
+	}
+	v, ok := pass.TypesInfo.Defs[identOf(r.Value)].(*types.Var)
+	if !ok {
+		return
+	}
+	var writes []ast.Node
+	var reads []token.Pos
+	escapes := false
+	inspectStack(r.Body, func(n ast.Node, stack []ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// 闭包可能在之后读取 v
+			escapes = escapes || refersTo(pass, n, v)
+			return false
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && copyRoot(pass, n.X) == v {
+				escapes = true
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if copyRoot(pass, lhs) == v {
+					writes = append(writes, n)
+				}
+			}
+		case *ast.IncDecStmt:
+			if copyRoot(pass, n.X) == v {
+				writes = append(writes, n)
+			}
+		case *ast.Ident:
+			if pass.TypesInfo.Uses[n] != v {
+				break
+			}
+			// 赋值语句左侧的 v 不算读取，v += 1 读到的也是修改之前的值
+			for i := len(stack) - 1; i >= 0; i-- {
+				switch s := stack[i].(type) {
+				case *ast.AssignStmt:
+					for _, lhs := range s.Lhs {
+						if n.Pos() >= lhs.Pos() && n.End() <= lhs.End() && copyRoot(pass, lhs) == v {
+							return true
+						}
+					}
+				case *ast.IncDecStmt:
+					if copyRoot(pass, s.X) == v {
+						return true
+					}
+				}
+				if _, ok := stack[i].(ast.Stmt); ok {
+					break
+				}
+			}
+			reads = append(reads, n.Pos())
+		}
+		return true
+	})
+	if escapes {
+		return
+	}
+	for _, w := range writes {
+		read := false
+		for _, p := range reads {
+			if p > w.End() {
+				read = true
+			}
+		}
+		if read {
+			continue
+		}
+		if _, isMap := pass.TypesInfo.TypeOf(r.X).Underlying().(*types.Map); isMap {
+			report(pass, rangeValueCopy, w, nil,
+				"%s 是 map %s 中值的副本，这里的修改不会写回 map，之后也没有再读取；修改后用 %s[k] = %s 写回，或让 map 保存指针（正确方式2）",
+				v.Name(), types.ExprString(r.X), types.ExprString(r.X), v.Name())
+		} else {
+			report(pass, rangeValueCopy, w, nil,
+				"%s 是 %s 中元素的副本，这里的修改不会写回 %s，之后也没有再读取；用 for i := range %s 按索引修改 %s[i]（正确方式1），或让切片保存指针（正确方式2）",
+				v.Name(), types.ExprString(r.X), types.ExprString(r.X), types.ExprString(r.X), types.ExprString(r.X))
+		}
+		return
+	}
+}
+
+// copyRoot 返回被修改的元素副本变量：e 是 v、v.f、v[i]（v 是数组）这样只修改 v 本身的表达式时返回 v；
+// 经过指针、切片或 map 的修改会写到共享的数据中，返回 nil
+func copyRoot(pass *analysis.Pass, e ast.Expr) *types.Var {
+	for {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			v, _ := pass.TypesInfo.Uses[x].(*types.Var)
+			return v
+		case *ast.SelectorExpr:
+			sel, ok := pass.TypesInfo.Selections[x]
+			if !ok || sel.Kind() != types.FieldVal || sel.Indirect() {
+				return nil
+			}
+			e = x.X
+		case *ast.IndexExpr:
+			if _, ok := pass.TypesInfo.TypeOf(x.X).Underlying().(*types.Array); !ok {
+				return nil
+			}
+			e = x.X
+		default:
+			return nil
+		}
+	}
+}
+
+// checkRangeAssign 报告循环体中对正在遍历的切片追加、原地删除元素，以及向正在遍历的 map 添加新键。
+// 修改之后紧跟 break 或 return 离开循环时不报告
+func checkRangeAssign(pass *analysis.Pass, r *ast.RangeStmt) {
+	x, ok := pass.TypesInfo.ObjectOf(identOf(r.X)).(*types.Var)
+	if !ok {
+		return
+	}
+	var key *types.Var
+	if r.Key != nil {
+		key, _ = pass.TypesInfo.ObjectOf(identOf(r.Key)).(*types.Var)
+	}
+	_, isMap := x.Type().Underlying().(*types.Map)
+	_, isSlice := x.Type().Underlying().(*types.Slice)
+	inspectStack(r.Body, func(n ast.Node, stack []ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		as, ok := n.(*ast.AssignStmt)
+		if !ok || len(as.Lhs) != len(as.Rhs) || leavesLoop(as, stack) {
+			return true
+		}
+		for i, lhs := range as.Lhs {
+			switch {
+			case isSlice && pass.TypesInfo.ObjectOf(identOf(lhs)) == x:
+				call, ok := ast.Unparen(as.Rhs[i]).(*ast.CallExpr)
+				if !ok || !isBuiltin(pass, call, "append") || len(call.Args) == 0 {
+					continue
+				}
+				if pass.TypesInfo.ObjectOf(identOf(call.Args[0])) == x {
+					report(pass, rangeAppend, as, nil,
+						"range 在开始时就确定了遍历的次数，循环中追加到 %s 的元素不会被遍历；把结果追加到新的切片（正确方式4），需要处理新元素时改用 for i := 0; i < len(%s); i++",
+						x.Name(), x.Name())
+				} else if se, ok := ast.Unparen(call.Args[0]).(*ast.SliceExpr); ok && call.Ellipsis.IsValid() && pass.TypesInfo.ObjectOf(identOf(se.X)) == x {
+					report(pass, rangeDelete, as, nil,
+						"遍历 %s 时原地删除元素，后面的元素会前移，紧跟在被删除元素之后的元素会被跳过，索引也不再对应；先收集要删除的索引再从后往前删除（正确方式3），或把保留的元素放进新切片（正确方式4）",
+						x.Name())
+				}
+			case isMap && as.Tok == token.ASSIGN:
+				index, ok := ast.Unparen(lhs).(*ast.IndexExpr)
+				if !ok || pass.TypesInfo.ObjectOf(identOf(index.X)) != x {
+					continue
+				}
+				if key != nil && pass.TypesInfo.ObjectOf(identOf(index.Index)) == key {
+					continue // 修改当前的键
+				}
+				report(pass, rangeMapInsert, as, nil,
+					"遍历 map %s 时添加新的键，新键是否会在这次遍历中出现是不确定的；先把新的键值对放进另一个 map，遍历结束后再合并",
+					x.Name())
+			}
+		}
+		return true
+	})
+}
+
+// leavesLoop 报告循环体中的语句 stmt 之后是否紧跟 return 或跳出这个循环的 break，stack 从循环体开始
+func leavesLoop(stmt ast.Stmt, stack []ast.Node) bool {
+	var list []ast.Stmt
+	switch b := stack[len(stack)-1].(type) {
+	case *ast.BlockStmt:
+		list = b.List
+	case *ast.CaseClause:
+		list = b.Body
+	case *ast.CommClause:
+		list = b.Body
+	}
+	for i, s := range list {
+		if s != stmt || i+1 >= len(list) {
+			continue
+		}
+		switch next := list[i+1].(type) {
+		case *ast.ReturnStmt:
+			return true
+		case *ast.BranchStmt:
+			if next.Tok != token.BREAK {
+				return false
+			}
+			if next.Label != nil {
+				return true // 带标签的 break 通常就是为了跳出外层循环
+			}
+			// 不带标签的 break 跳出最内层的 for、switch 或 select，stack 从 r 的循环体开始，其中不能再有这些语句
+			for j := len(stack) - 1; j >= 0; j-- {
+				switch stack[j].(type) {
+				case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package channel_close
+
+func neverClosed(items []int) int {
+	ch := make(chan int) // want `channel_close`
+	go func() {
+		for _, v := range items {
+			ch <- v
+		}
+	}()
+	sum := 0
+	for v := range ch {
+		sum += v
+	}
+	return sum
+}
+
+func commaOk() {
+	done := make(chan struct{}) // want `channel_close`
+	go func() {
+		done <- struct{}{}
+	}()
+	for {
+		if _, ok := <-done; !ok {
+			return
+		}
+	}
+}
+
+// 以下不应报告
+
+func closed(items []int) int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range items {
+			ch <- v
+		}
+	}()
+	sum := 0
+	for v := range ch {
+		sum += v
+	}
+	return sum
+}
+
+func counted(items []int) int {
+	ch := make(chan int)
+	for _, v := range items {
+		go func() { ch <- v }()
+	}
+	sum := 0
+	for range items {
+		sum += <-ch
+	}
+	return sum
+}
+
+func produce(ch chan int) {}
+
+func escapes() {
+	ch := make(chan int)
+	go produce(ch)
+	for v := range ch {
+		println(v)
+	}
+}
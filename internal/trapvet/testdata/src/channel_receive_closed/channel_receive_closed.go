@@ -0,0 +1,70 @@
+package channel_receive_closed
+
+func singleValue(items []int) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range items {
+			ch <- v
+		}
+	}()
+	for {
+		v := <-ch // want `channel_receive_closed`
+		println(v)
+	}
+}
+
+// 以下不应报告
+
+func commaOk(items []int) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range items {
+			ch <- v
+		}
+	}()
+	for {
+		v, ok := <-ch
+		if !ok {
+			return
+		}
+		println(v)
+	}
+}
+
+func rangeChan(items []int) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range items {
+			ch <- v
+		}
+	}()
+	for v := range ch {
+		println(v)
+	}
+}
+
+func notClosed() {
+	ch := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			ch <- i
+		}
+	}()
+	for {
+		println(<-ch)
+	}
+}
+
+func counted(n int) {
+	ch := make(chan int, n)
+	for i := 0; i < n; i++ {
+		ch <- i
+	}
+	close(ch)
+	for i := 0; i < n; i++ {
+		println(<-ch)
+	}
+}
@@ -0,0 +1,86 @@
+package channel_select_default
+
+import "time"
+
+func busy(jobs <-chan int, quit <-chan struct{}) {
+	for {
+		select {
+		case j := <-jobs:
+			println(j)
+		case <-quit:
+			return
+		default: // want `channel_select_default`
+		}
+	}
+}
+
+func busyCounter(jobs <-chan int) int {
+	idle := 0
+	for {
+		select {
+		case j, ok := <-jobs:
+			if !ok {
+				return idle
+			}
+			println(j)
+		default: // want `channel_select_default`
+			idle++
+		}
+	}
+}
+
+func busyCond(jobs <-chan int, running *bool) {
+	for *running {
+		select {
+		case j := <-jobs:
+			println(j)
+		default: // want `channel_select_default`
+		}
+	}
+}
+
+// 以下不应报告
+
+func blocking(jobs <-chan int, quit <-chan struct{}) {
+	for {
+		select {
+		case j := <-jobs:
+			println(j)
+		case <-quit:
+			return
+		}
+	}
+}
+
+func doWork() {}
+
+func work(jobs <-chan int) {
+	for {
+		select {
+		case j := <-jobs:
+			println(j)
+		default:
+			doWork()
+		}
+	}
+}
+
+func sleep(jobs <-chan int) {
+	for {
+		select {
+		case j := <-jobs:
+			println(j)
+		default:
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func nonBlockingSend(ch chan<- int, v int) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package channel_send_closed
+
+import "sync"
+
+func sendAfterClose() {
+	ch := make(chan int, 1)
+	close(ch)
+	ch <- 1 // want `send_after_close`
+}
+
+func doubleClose() {
+	ch := make(chan int)
+	close(ch)
+	close(ch) // want `double_close`
+}
+
+func closeInLoop(vals []int) {
+	out := make(chan int, len(vals))
+	for _, v := range vals {
+		out <- v // want `send_after_close`
+		if v < 0 {
+			close(out)
+		}
+	}
+}
+
+func senderGoroutineAfterClose() {
+	ch := make(chan int)
+	close(ch)
+	go func() {
+		ch <- 1 // want `send_after_close`
+	}()
+}
+
+func receiverCloses() {
+	ch := make(chan int)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- i // want `send_after_close`
+		}
+	}()
+	<-ch
+	close(ch) // want `receiver_close`
+}
+
+func closeParam(ch chan int) {
+	for range ch {
+	}
+	close(ch) // want `receiver_close`
+}
+
+// 以下不应报告
+
+func closeThenReturn(cond bool) {
+	ch := make(chan int, 1)
+	if cond {
+		close(ch)
+		return
+	}
+	ch <- 1
+}
+
+func exclusiveBranches(a bool) {
+	ch := make(chan int, 1)
+	if a {
+		close(ch)
+	} else {
+		ch <- 1
+	}
+}
+
+func producer(vals []int) {
+	out := make(chan int, len(vals))
+	for _, v := range vals {
+		if v < 0 {
+			close(out)
+			return
+		}
+		out <- v
+	}
+	close(out)
+}
+
+func closePanics(cond bool) {
+	ch := make(chan int, 1)
+	if cond {
+		close(ch)
+		panic("closed")
+	}
+	ch <- 1
+}
+
+func exclusiveCases(n int) {
+	ch := make(chan int, 1)
+	switch n {
+	case 0:
+		close(ch)
+	default:
+		ch <- n
+		close(ch)
+	}
+}
+
+func closeOnce() {
+	ch := make(chan int)
+	var once sync.Once
+	for i := 0; i < 2; i++ {
+		once.Do(func() { close(ch) })
+	}
+}
+
+func closeAfterSend() {
+	ch := make(chan int, 1)
+	ch <- 1
+	close(ch)
+}
+
+func waitThenClose() {
+	ch := make(chan int, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ch <- i
+		}()
+	}
+	wg.Wait()
+	close(ch)
+}
@@ -0,0 +1,55 @@
+package defer_order
+
+import "fmt"
+
+func argsEvaluated() {
+	x := 1
+	defer fmt.Println(x) // want `defer_order`
+	x = 2
+}
+
+func argsIncremented() {
+	count := 0
+	defer fmt.Println("count:", count) // want `defer_order`
+	for i := 0; i < 3; i++ {
+		count++
+	}
+}
+
+func deferredResult() int {
+	result := 1
+	defer func() {
+		result *= 2
+	}()
+	return result // want `defer_order`
+}
+
+// 以下不应报告
+
+func closure() {
+	x := 1
+	defer func() {
+		fmt.Println(x)
+	}()
+	x = 2
+}
+
+func notModified() {
+	x := 1
+	defer fmt.Println(x)
+	fmt.Println("done")
+}
+
+func namedResult() (result int) {
+	defer func() {
+		result *= 2
+	}()
+	result = 1
+	return result
+}
+
+func modifiedBefore() {
+	x := 1
+	x = 2
+	defer fmt.Println(x)
+}
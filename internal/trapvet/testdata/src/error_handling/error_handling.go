@@ -0,0 +1,159 @@
+package error_handling
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var ErrNotFound = errors.New("not found")
+
+func deferBeforeCheck(name string) error {
+	f, err := os.Open(name)
+	defer f.Close() // want `error_handling/defer_before_check`
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func ignoredError(name string) {
+	f, _ := os.Open(name)
+	defer f.Close() // want `error_handling/defer_before_check`
+}
+
+func deferInLoop(names []string) {
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			continue
+		}
+		defer f.Close() // want `error_handling/defer_in_loop`
+		println(f.Name())
+	}
+}
+
+func deferInLoopReturn(names []string) error {
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // want `error_handling/defer_in_loop`
+	}
+	return nil
+}
+
+func find(key string) error {
+	return fmt.Errorf("find %q: %w", key, ErrNotFound)
+}
+
+func lookup(key string) error {
+	return find(key)
+}
+
+func compareWrapped(key string) bool {
+	err := lookup(key)
+	return err == ErrNotFound // want `error_handling/equal_compare`
+}
+
+func findVar(key string) error {
+	err := fmt.Errorf("find %q: %w", key, ErrNotFound)
+	return err
+}
+
+func findNamed(key string) (err error) {
+	err = fmt.Errorf("find %q: %w", key, ErrNotFound)
+	return
+}
+
+func compareWrappedVar(key string) bool {
+	return findVar(key) == ErrNotFound // want `error_handling/equal_compare`
+}
+
+func compareWrappedNamed(key string) bool {
+	err := findNamed(key)
+	return err != ErrNotFound // want `error_handling/equal_compare`
+}
+
+func compareNew(err error) bool {
+	return err == errors.New("not found") // want `error_handling/equal_compare`
+}
+
+func wrapVerb(name string) error {
+	if _, err := os.Stat(name); err != nil {
+		return fmt.Errorf("stat %s: %v", name, err) // want `error_handling/wrap_verb`
+	}
+	return nil
+}
+
+func wrapVerbFlag(name string) error {
+	if _, err := os.Stat(name); err != nil {
+		return fmt.Errorf("stat %s: %+v", name, err) // want `error_handling/wrap_verb`
+	}
+	return nil
+}
+
+// 以下不应报告
+
+func checkThenDefer(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return nil
+}
+
+func closurePerIteration(names []string) {
+	for _, name := range names {
+		func() {
+			f, err := os.Open(name)
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			println(f.Name())
+		}()
+	}
+}
+
+func errorsIs(key string) bool {
+	return errors.Is(lookup(key), ErrNotFound)
+}
+
+func readAll(r io.Reader) error {
+	buf := make([]byte, 64)
+	for {
+		_, err := r.Read(buf)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func replaced(key string) error {
+	err := fmt.Errorf("find %q: %w", key, ErrNotFound)
+	println(err.Error())
+	err = errors.New("bad key")
+	return err
+}
+
+func compareReplaced(key string) bool {
+	return replaced("k") == ErrNotFound
+}
+
+func wrapped(name string) error {
+	if _, err := os.Stat(name); err != nil {
+		return fmt.Errorf("stat %s: %w", name, err)
+	}
+	return nil
+}
+
+func notError(name string, size int) error {
+	return fmt.Errorf("%s: size %v", name, size)
+}
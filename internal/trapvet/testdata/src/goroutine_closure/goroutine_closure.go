@@ -0,0 +1,106 @@
+package goroutine_closure
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+func sharedCounter() int {
+	count := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count++ // want `goroutine_closure`
+		}()
+	}
+	wg.Wait()
+	return count
+}
+
+func sharedResult(items []string) string {
+	var last string
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last = item // want `goroutine_closure`
+		}()
+	}
+	wg.Wait()
+	return last
+}
+
+// 以下不应报告
+
+func locked() int {
+	count := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			count++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return count
+}
+
+func atomicCounter() int64 {
+	var count atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count.Add(1)
+		}()
+	}
+	wg.Wait()
+	return count.Load()
+}
+
+func perIteration(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := len(item)
+			n++
+			_ = n
+		}()
+	}
+	wg.Wait()
+}
+
+func loopVarGo122(items []string) []int {
+	lens := make([]int, len(items))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lens[i] = len(item)
+		}()
+	}
+	wg.Wait()
+	return lens
+}
+
+func outsideLoop() int {
+	count := 0
+	done := make(chan struct{})
+	go func() {
+		count++
+		close(done)
+	}()
+	<-done
+	return count
+}
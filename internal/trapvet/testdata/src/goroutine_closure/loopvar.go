@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package goroutine_closure
+
+import "sync"
+
+func captureLoopVar(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func() { // want `循环变量 item`
+			defer wg.Done()
+			println(item)
+		}()
+	}
+	wg.Wait()
+}
+
+// 以下不应报告
+
+func passLoopVar(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			println(item)
+		}(item)
+	}
+	wg.Wait()
+}
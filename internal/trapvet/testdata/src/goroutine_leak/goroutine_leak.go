@@ -0,0 +1,139 @@
+package goroutine_leak
+
+import (
+	"context"
+	"time"
+)
+
+func neverSent() {
+	ch := make(chan int)
+	go func() {
+		println(<-ch) // want `goroutine_leak/unmatched`
+	}()
+}
+
+func neverReceived() {
+	ch := make(chan int)
+	go func() {
+		ch <- 1 // want `goroutine_leak/unmatched`
+	}()
+}
+
+func neverClosed() {
+	ch := make(chan int)
+	go func() {
+		for v := range ch { // want `goroutine_leak/unmatched`
+			println(v)
+		}
+	}()
+}
+
+func compute() int { return 1 }
+
+func timeout() (int, bool) {
+	result := make(chan int)
+	go func() {
+		result <- compute() // want `goroutine_leak/abandoned_sender`
+	}()
+	select {
+	case v := <-result:
+		return v, true
+	case <-time.After(time.Second):
+		return 0, false
+	}
+}
+
+func timeoutLoop(n int) (int, bool) {
+	result := make(chan int)
+	go func() {
+		for i := 0; i < n; i++ {
+			result <- i // want `goroutine_leak/abandoned_sender`
+		}
+	}()
+	select {
+	case v := <-result:
+		return v, true
+	case <-time.After(time.Second):
+		return 0, false
+	}
+}
+
+// 以下不应报告
+
+func sentLater() {
+	ch := make(chan int)
+	go func() {
+		println(<-ch)
+	}()
+	ch <- 1
+}
+
+func closedLater() {
+	ch := make(chan int)
+	go func() {
+		for v := range ch {
+			println(v)
+		}
+	}()
+	close(ch)
+}
+
+func receivedLater() int {
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+	}()
+	return <-ch
+}
+
+func bufferedSend() {
+	ch := make(chan int, 1)
+	go func() {
+		ch <- 1
+	}()
+}
+
+func escapes() chan int {
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+	}()
+	return ch
+}
+
+func bufferedResult() (int, bool) {
+	result := make(chan int, 1)
+	go func() {
+		result <- compute()
+	}()
+	select {
+	case v := <-result:
+		return v, true
+	case <-time.After(time.Second):
+		return 0, false
+	}
+}
+
+func selectSend(ctx context.Context) (int, bool) {
+	result := make(chan int)
+	go func() {
+		select {
+		case result <- compute():
+		case <-ctx.Done():
+		}
+	}()
+	select {
+	case v := <-result:
+		return v, true
+	case <-time.After(time.Second):
+		return 0, false
+	}
+}
+
+func plainReceive() int {
+	result := make(chan int)
+	go func() {
+		result <- compute()
+	}()
+	return <-result
+}
@@ -0,0 +1,75 @@
+package goroutine_wait
+
+import (
+	"sync"
+	"time"
+)
+
+func fireAndForget(items []string) {
+	for _, item := range items {
+		go func() { // want `goroutine_wait`
+			println(item)
+		}()
+	}
+}
+
+func nested(rows [][]int) {
+	for _, row := range rows {
+		for _, v := range row {
+			go func() { // want `goroutine_wait`
+				println(v)
+			}()
+		}
+	}
+}
+
+// 以下不应报告
+
+func waitGroup(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			println(item)
+		}()
+	}
+	wg.Wait()
+}
+
+func channelResults(items []string) []int {
+	results := make(chan int, len(items))
+	for _, item := range items {
+		go func() {
+			results <- len(item)
+		}()
+	}
+	lens := make([]int, 0, len(items))
+	for range items {
+		lens = append(lens, <-results)
+	}
+	return lens
+}
+
+func sleep(items []string) {
+	for _, item := range items {
+		go func() {
+			println(item)
+		}()
+	}
+	time.Sleep(time.Second)
+}
+
+func work(item string) {}
+
+func namedFunc(items []string) {
+	for _, item := range items {
+		go work(item)
+	}
+}
+
+func single(item string) {
+	go func() {
+		println(item)
+	}()
+}
@@ -0,0 +1,109 @@
+package interface_assertion
+
+type Animal interface {
+	Sound() string
+}
+
+type Cat struct{}
+
+func (Cat) Sound() string { return "meow" }
+
+type Dog struct{ Name string }
+
+func (Dog) Sound() string { return "woof" }
+
+func define(a Animal) {
+	cat := a.(Cat) // want `interface_assertion`
+	println(cat.Sound())
+}
+
+func inReturn(a Animal) (string, error) {
+	return a.(*Dog).Name, nil // want `interface_assertion`
+}
+
+func zeroValues(v any) (int, string) {
+	n := v.(int) + 1 // want `interface_assertion`
+	return n, "ok"
+}
+
+func inCondition(a Animal, loud bool) bool {
+	return loud && a.(Dog).Name != "" // want `interface_assertion`
+}
+
+func reassigned(a, b Animal) string {
+	_, ok := a.(Cat)
+	if !ok {
+		return ""
+	}
+	a = b
+	return a.(Cat).Sound() // want `interface_assertion`
+}
+
+func reassignedInBranch(a, b Animal) string {
+	if _, ok := a.(Dog); ok {
+		a = b
+		return a.(Dog).Name // want `interface_assertion`
+	}
+	return ""
+}
+
+func reassignedInCase(a, b Animal) string {
+	switch a.(type) {
+	case Dog:
+		a = b
+		return a.(Dog).Name // want `interface_assertion`
+	}
+	return ""
+}
+
+func namedV(v any) {
+	println(v.(string)) // want `interface_assertion`
+}
+
+// 以下不应报告
+
+func commaOk(a Animal) {
+	cat, ok := a.(Cat)
+	if !ok {
+		return
+	}
+	println(cat.Sound())
+}
+
+func typeSwitch(a Animal) string {
+	switch a.(type) {
+	case Dog:
+		return a.(Dog).Name
+	}
+	return ""
+}
+
+func okBranch(a Animal) string {
+	if _, ok := a.(Dog); ok {
+		return a.(Dog).Name
+	}
+	return ""
+}
+
+func earlyReturn(a Animal) string {
+	_, ok := a.(Dog)
+	if !ok {
+		return ""
+	}
+	return a.(Dog).Name
+}
+
+func reassignedAfterUse(a, b Animal) string {
+	_, ok := a.(Dog)
+	if !ok {
+		return ""
+	}
+	name := a.(Dog).Name
+	a = b
+	return name + a.Sound()
+}
+
+func onlyInt() int {
+	var v any = 42
+	return v.(int)
+}
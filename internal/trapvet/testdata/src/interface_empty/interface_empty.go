@@ -0,0 +1,40 @@
+package interface_empty
+
+func mixed() {
+	var v interface{} // want `interface_empty`
+	v = 42
+	v = "hello"
+	println(v)
+}
+
+func three() {
+	var data any // want `interface_empty`
+	data = 1.5
+	data = true
+	data = []int{1}
+	println(data)
+}
+
+// 以下不应报告
+
+func single() {
+	var v interface{}
+	v = 1
+	v = 2
+	println(v)
+}
+
+func concrete() {
+	var n int
+	n = 1
+	println(n)
+}
+
+type Stringer interface{ String() string }
+
+func fromInterface(s Stringer) {
+	var v any
+	v = s
+	v = 1
+	println(v)
+}
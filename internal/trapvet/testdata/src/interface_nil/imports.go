@@ -0,0 +1,12 @@
+package interface_nil
+
+import (
+	"bytes"
+	"io"
+)
+
+// 删除声明后 bytes 就没有用了，不提供修复
+func nilBuffer() io.Reader {
+	var b *bytes.Buffer
+	return b // want `interface_nil`
+}
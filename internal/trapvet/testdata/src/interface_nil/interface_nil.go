@@ -0,0 +1,90 @@
+package interface_nil
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type MyError struct{}
+
+func (e *MyError) Error() string { return "my error" }
+
+func alwaysNil() error {
+	var err *MyError
+	return err // want `interface_nil`
+}
+
+func stdout() io.Writer { return os.Stdout }
+
+func nilFile() io.Reader {
+	var f *os.File
+	return f // want `interface_nil`
+}
+
+func definedNil() error {
+	err := (*MyError)(nil)
+	return err // want `interface_nil`
+}
+
+func usedElsewhere() error {
+	var err *MyError
+	fmt.Println(err)
+	return err // want `interface_nil`
+}
+
+func sharedDecl() (error, error) {
+	var a, b *MyError
+	return a, b // want `interface_nil` `interface_nil`
+}
+
+func conversion() error {
+	return (*MyError)(nil) // want `interface_nil`
+}
+
+func someBranches(fail bool) error {
+	var err *MyError
+	if fail {
+		err = &MyError{}
+	}
+	return err // want `interface_nil`
+}
+
+// 以下不应报告
+
+func assigned() error {
+	var err *MyError
+	err = &MyError{}
+	return err
+}
+
+func checked(fail bool) error {
+	var err *MyError
+	if fail {
+		err = &MyError{}
+	}
+	if err == nil {
+		return nil
+	}
+	return err
+}
+
+func allBranches(fail bool) error {
+	var err *MyError
+	if fail {
+		err = &MyError{}
+	} else {
+		return nil
+	}
+	return err
+}
+
+func pointerResult() *MyError {
+	var err *MyError
+	return err
+}
+
+func interfaceVar() error {
+	var err error
+	return err
+}
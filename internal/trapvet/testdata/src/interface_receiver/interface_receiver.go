@@ -0,0 +1,50 @@
+package interface_receiver
+
+type Counter interface {
+	Inc()
+	Value() int
+}
+
+type Clicks struct{ n int }
+
+func (c Clicks) Inc()       { c.n++ }
+func (c Clicks) Value() int { return c.n }
+
+type Hits struct{ n int }
+
+func (h *Hits) Inc()       { h.n++ }
+func (h *Hits) Value() int { return h.n }
+
+func use(c Counter) { c.Inc() }
+
+func assign() {
+	var c Counter = Clicks{} // want `interface_receiver`
+	c.Inc()
+}
+
+func argument() {
+	use(Clicks{}) // want `interface_receiver`
+}
+
+func result() Counter {
+	return Clicks{} // want `interface_receiver`
+}
+
+func reassign(c Counter) {
+	c = Clicks{n: 1} // want `interface_receiver`
+	c.Inc()
+}
+
+// 以下不应报告
+
+func pointerReceiver() {
+	var c Counter = &Hits{}
+	c.Inc()
+	use(&Hits{})
+}
+
+type Reader interface{ Value() int }
+
+func readOnly() Reader {
+	return Clicks{}
+}
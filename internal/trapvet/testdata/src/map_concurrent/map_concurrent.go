@@ -0,0 +1,82 @@
+package map_concurrent
+
+import "sync"
+
+func writersInLoop() {
+	m := make(map[int]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m[i] = i // want `map_concurrent`
+		}()
+	}
+	wg.Wait()
+}
+
+func writeWhileReading() int {
+	m := make(map[string]int)
+	go func() {
+		m["a"] = 1 // want `map_concurrent`
+	}()
+	return m["a"]
+}
+
+func twoGoroutines() {
+	m := make(map[string]int)
+	done := make(chan bool)
+	go func() {
+		delete(m, "a") // want `map_concurrent`
+		done <- true
+	}()
+	go func() {
+		_ = m["a"]
+		done <- true
+	}()
+	<-done
+	<-done
+}
+
+// 以下不应报告
+
+func locked() {
+	m := make(map[int]int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			m[i] = i
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+func waitThenRead() int {
+	m := make(map[string]int)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m["a"] = 1
+	}()
+	wg.Wait()
+	return m["a"]
+}
+
+func readOnly(keys []string) {
+	m := map[string]int{"a": 1}
+	var wg sync.WaitGroup
+	for _, k := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = m[k]
+		}()
+	}
+	wg.Wait()
+}
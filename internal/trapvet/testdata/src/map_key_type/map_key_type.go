@@ -0,0 +1,40 @@
+package map_key_type
+
+func sliceKey() {
+	m := make(map[any]int)
+	m[[]int{1, 2}] = 1 // want `map_key_type`
+}
+
+func funcKey(m map[interface{}]string, f func()) string {
+	return m[f] // want `map_key_type`
+}
+
+func mapKeyDelete(m map[any]bool, k map[string]int) {
+	delete(m, k) // want `map_key_type`
+}
+
+func literal() map[any]int {
+	return map[any]int{
+		[]string{"a"}: 1, // want `map_key_type`
+	}
+}
+
+type Point struct{ X, Y int }
+
+// 以下不应报告
+
+func comparable() {
+	m := make(map[any]int)
+	m[1] = 1
+	m["a"] = 2
+	m[Point{1, 2}] = 3
+	m[[2]int{1, 2}] = 4
+}
+
+func interfaceValue(m map[any]int, k any) int {
+	return m[k]
+}
+
+func stringKey(m map[string]int) int {
+	return m["a"]
+}
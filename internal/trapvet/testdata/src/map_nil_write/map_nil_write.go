@@ -0,0 +1,114 @@
+package map_nil_write
+
+type Counter struct {
+	counts map[string]int
+	name   string
+}
+
+func declared() {
+	var m map[string]int
+	m["a"] = 1 // want `map_nil_write`
+}
+
+func grouped() {
+	var (
+		m    map[string]int
+		name = "a"
+	)
+	m[name]++ // want `map_nil_write`
+}
+
+func zeroField() {
+	var c Counter
+	c.name = "c"
+	c.counts["a"] = 1 // want `map_nil_write`
+}
+
+func literalField() {
+	c := Counter{name: "c"}
+	c.counts["a"]++ // want `map_nil_write`
+}
+
+func record(visits map[string]int, page string) {
+	visits[page]++ // want `map_nil_write`
+}
+
+func callers() {
+	record(nil, "/")
+	record(nil, "/about")
+}
+
+func inSwitch(kind int) {
+	switch kind {
+	case 1:
+		var m map[string]int
+		m["a"] = 1 // want `map_nil_write`
+	default:
+		var c Counter
+		c.counts["b"]++ // want `map_nil_write`
+	}
+}
+
+func inSelect(ch chan string) {
+	select {
+	case key := <-ch:
+		var m map[string]bool
+		m[key] = true // want `map_nil_write`
+	}
+}
+
+// 以下不应报告
+
+func made() {
+	m := make(map[string]int)
+	m["a"] = 1
+}
+
+func assignedLater() {
+	var m map[string]int
+	m = map[string]int{}
+	m["a"] = 1
+}
+
+func checked(m map[string]int) {
+	if m == nil {
+		return
+	}
+	m["a"] = 1
+}
+
+func lazyInit() {
+	var m map[string]int
+	if m == nil {
+		m = make(map[string]int)
+	}
+	m["a"] = 1
+}
+
+func readOnly() int {
+	var m map[string]int
+	return m["a"]
+}
+
+func initField() {
+	var c Counter
+	c.counts = make(map[string]int)
+	c.counts["a"] = 1
+}
+
+func sometimesNil(m map[string]int) {
+	m["a"] = 1
+}
+
+func mixedCallers() {
+	sometimesNil(nil)
+	sometimesNil(map[string]int{})
+}
+
+func Exported(m map[string]int) {
+	m["a"] = 1
+}
+
+func callExported() {
+	Exported(nil)
+}
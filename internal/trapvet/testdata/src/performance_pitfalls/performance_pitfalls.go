@@ -0,0 +1,112 @@
+package performance_pitfalls
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type User struct{ Name string }
+
+func join(words []string) string {
+	s := ""
+	for _, w := range words {
+		s += w // want `performance_pitfalls/string_concat`
+	}
+	return s
+}
+
+func csv(nums []int) string {
+	var out string
+	for _, n := range nums {
+		out = out + fmt.Sprintf("%d,", n) // want `performance_pitfalls/string_concat`
+	}
+	return out
+}
+
+func concatAssigned(words []string) string {
+	s := ""
+	for _, w := range words {
+		s += w // want `performance_pitfalls/string_concat`
+	}
+	s = strings.TrimSpace(s)
+	return s
+}
+
+func squares(n int) []int {
+	var out []int
+	for i := 0; i < n; i++ {
+		out = append(out, i*i) // want `performance_pitfalls/append_prealloc`
+	}
+	return out
+}
+
+func names(users []User) []string {
+	result := []string{}
+	for _, u := range users {
+		result = append(result, u.Name) // want `performance_pitfalls/append_prealloc`
+	}
+	return result
+}
+
+func build(nums []int) string {
+	var b strings.Builder
+	for _, n := range nums {
+		b.WriteString(fmt.Sprintf("%d ", n)) // want `performance_pitfalls/sprintf_write`
+	}
+	return b.String()
+}
+
+func writeName(buf *bytes.Buffer, name string) {
+	buf.WriteString(fmt.Sprint(name)) // want `performance_pitfalls/sprintf_write`
+}
+
+// 以下不应报告
+
+func builder(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		b.WriteString(w)
+	}
+	return b.String()
+}
+
+func concatOnce(a, b string) string {
+	s := a
+	s += b
+	return s
+}
+
+func preallocated(n int) []int {
+	out := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, i*i)
+	}
+	return out
+}
+
+func filtered(nums []int) []int {
+	var out []int
+	for _, n := range nums {
+		if n > 0 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func untilZero(nums []int) []int {
+	var out []int
+	for _, n := range nums {
+		if n == 0 {
+			break
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+func writePlain(b *strings.Builder, name string) {
+	b.WriteString(name)
+	fmt.Fprintf(b, "%s\n", name)
+}
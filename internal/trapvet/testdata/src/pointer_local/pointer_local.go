@@ -0,0 +1,44 @@
+package pointer_local
+
+import "unsafe"
+
+var saved uintptr
+
+func returnAddr() uintptr {
+	x := 42
+	return uintptr(unsafe.Pointer(&x)) // want `pointer_local`
+}
+
+func saveAddr() {
+	var buf [16]byte
+	saved = uintptr(unsafe.Pointer(&buf)) // want `pointer_local`
+}
+
+func declAddr() uintptr {
+	n := 1
+	var p = uintptr(unsafe.Pointer(&n)) // want `pointer_local`
+	return p
+}
+
+// 以下不应报告
+
+func returnPointer() *int {
+	x := 42
+	return &x
+}
+
+func unsafePointer() unsafe.Pointer {
+	x := 42
+	return unsafe.Pointer(&x)
+}
+
+var global int
+
+func globalAddr() uintptr {
+	return uintptr(unsafe.Pointer(&global))
+}
+
+func size() uintptr {
+	var x int64
+	return unsafe.Sizeof(x)
+}
@@ -0,0 +1,63 @@
+package pointer_nil
+
+type User struct {
+	Name string
+}
+
+func field() string {
+	var u *User
+	return u.Name // want `pointer_nil`
+}
+
+func star() int {
+	var p *int
+	*p = 1 // want `pointer_nil`
+	return *p
+}
+
+func afterOtherStmt() {
+	var u *User
+	println("start")
+	u.Name = "a" // want `pointer_nil`
+}
+
+// 以下不应报告
+
+func assigned() string {
+	var u *User
+	u = &User{Name: "a"}
+	return u.Name
+}
+
+func checked() string {
+	var u *User
+	if u != nil {
+		return u.Name
+	}
+	return ""
+}
+
+func initialized() string {
+	var u = &User{}
+	return u.Name
+}
+
+func newUser() *User { return &User{} }
+
+func assignedByCall() string {
+	var u *User
+	u = newUser()
+	return u.Name
+}
+
+func deferredUse() func() string {
+	var u *User
+	f := func() string { return u.Name }
+	u = newUser()
+	return f
+}
+
+func notPointer() string {
+	var u User
+	return u.Name
+}
@@ -0,0 +1,54 @@
+package pointer_receiver
+
+type Counter struct {
+	count int
+	stats struct {
+		calls int
+	}
+	labels map[string]string
+	next   *Counter
+}
+
+func (c Counter) Inc() {
+	c.count++ // want `pointer_receiver`
+}
+
+func (c Counter) Reset() {
+	c.count = 0 // want `pointer_receiver`
+}
+
+func (c Counter) Call() {
+	c.stats.calls += 1 // want `pointer_receiver`
+}
+
+// 以下不应报告
+
+func (c *Counter) IncPtr() {
+	c.count++
+}
+
+func (c Counter) With(n int) Counter {
+	c.count = n
+	return c
+}
+
+func (c Counter) Label(k, v string) {
+	c.labels[k] = v
+}
+
+func (c Counter) Link() {
+	c.next.count = 1
+}
+
+func (c Counter) Value() int {
+	n := c.count
+	n++
+	return n
+}
+
+type ID int
+
+func (id ID) Next() ID {
+	id++
+	return id
+}
@@ -0,0 +1,43 @@
+package slice_array
+
+func overwrite() ([]int, []int) {
+	original := []int{1, 2, 3, 4}
+	sub := original[1:3]
+	grown := append(sub, 5) // want `slice_array`
+	return original, grown
+}
+
+func noHigh(nums []int) []int {
+	tail := nums[2:]
+	more := append(tail, 0) // want `slice_array`
+	return more
+}
+
+// 以下不应报告
+
+func limited() ([]int, []int) {
+	original := []int{1, 2, 3, 4}
+	sub := original[1:3:3]
+	grown := append(sub, 5)
+	return original, grown
+}
+
+func sameVar(nums []int) []int {
+	sub := nums[:2]
+	sub = append(sub, 9)
+	return sub
+}
+
+func copied(nums []int) []int {
+	sub := make([]int, 2)
+	copy(sub, nums[:2])
+	grown := append(sub, 9)
+	return grown
+}
+
+func reassignedSub(nums, other []int) []int {
+	sub := nums[:2]
+	sub = other
+	grown := append(sub, 9)
+	return grown
+}
@@ -0,0 +1,47 @@
+//go:build go1.21
+
+package slice_pointer
+
+var last *int
+
+func appendLoopVar(nums []int) []*int {
+	var ptrs []*int
+	for _, n := range nums {
+		ptrs = append(ptrs, &n) // want `slice_pointer/loop_var`
+	}
+	return ptrs
+}
+
+func saveLoopVar(nums []int) map[int]*int {
+	byIndex := make(map[int]*int)
+	for i, n := range nums {
+		byIndex[i] = &n // want `slice_pointer/loop_var`
+	}
+	return byIndex
+}
+
+func globalLoopVar(nums []int) {
+	for i := 0; i < len(nums); i++ {
+		last = &i // want `slice_pointer/loop_var`
+	}
+}
+
+// 以下不应报告
+
+func copyLoopVar(nums []int) []*int {
+	var ptrs []*int
+	for _, n := range nums {
+		n := n
+		ptrs = append(ptrs, &n)
+	}
+	return ptrs
+}
+
+func localUse(nums []int) int {
+	sum := 0
+	for _, n := range nums {
+		p := &n
+		sum += *p
+	}
+	return sum
+}
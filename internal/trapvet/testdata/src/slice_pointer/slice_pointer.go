@@ -0,0 +1,47 @@
+package slice_pointer
+
+type Item struct {
+	Name string
+}
+
+func staleElement(items []Item) {
+	first := &items[0]
+	items = append(items, Item{Name: "new"})
+	first.Name = "changed" // want `slice_pointer/stale_element`
+}
+
+func staleField(items []Item) string {
+	name := &items[0].Name
+	items = append(items, Item{})
+	return *name // want `slice_pointer/stale_element`
+}
+
+// 以下不应报告
+
+func reacquire(items []Item) {
+	first := &items[0]
+	first.Name = "a"
+	items = append(items, Item{})
+	first = &items[0]
+	first.Name = "b"
+}
+
+func useBeforeAppend(items []Item) []Item {
+	first := &items[0]
+	first.Name = "a"
+	return append(items, Item{})
+}
+
+func index(items []Item) {
+	i := 0
+	items = append(items, Item{})
+	items[i].Name = "a"
+}
+
+func loopVarGo122(nums []int) []*int {
+	var ptrs []*int
+	for _, n := range nums {
+		ptrs = append(ptrs, &n)
+	}
+	return ptrs
+}
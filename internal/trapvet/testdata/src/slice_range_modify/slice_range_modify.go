@@ -0,0 +1,89 @@
+package slice_range_modify
+
+type User struct {
+	Name   string
+	Active bool
+}
+
+func valueCopy(users []User) {
+	for _, u := range users {
+		u.Active = true // want `slice_range_modify/value_copy`
+	}
+}
+
+func mapValueCopy(users map[string]User) {
+	for _, u := range users {
+		u.Name = "x" // want `slice_range_modify/value_copy`
+	}
+}
+
+func appendWhileRange(nums []int) []int {
+	for _, n := range nums {
+		if n > 0 {
+			nums = append(nums, -n) // want `slice_range_modify/append`
+		}
+	}
+	return nums
+}
+
+func deleteWhileRange(nums []int) []int {
+	for i, n := range nums {
+		if n < 0 {
+			nums = append(nums[:i], nums[i+1:]...) // want `slice_range_modify/delete`
+		}
+	}
+	return nums
+}
+
+func mapInsert(m map[string]int) {
+	for k, v := range m {
+		m[k+"_copy"] = v // want `slice_range_modify/map_insert`
+	}
+}
+
+// 以下不应报告
+
+func byIndex(users []User) {
+	for i := range users {
+		users[i].Active = true
+	}
+}
+
+func copyThenUse(users []User) []string {
+	var names []string
+	for _, u := range users {
+		u.Name = "Mr. " + u.Name
+		names = append(names, u.Name)
+	}
+	return names
+}
+
+func pointers(users []*User) {
+	for _, u := range users {
+		u.Active = true
+	}
+}
+
+func newSlice(nums []int) []int {
+	var out []int
+	for _, n := range nums {
+		out = append(out, n, -n)
+	}
+	return out
+}
+
+func deleteThenBreak(nums []int, target int) []int {
+	for i, n := range nums {
+		if n == target {
+			nums = append(nums[:i], nums[i+1:]...)
+			break
+		}
+	}
+	return nums
+}
+
+func updateKey(m map[string]int) {
+	for k, v := range m {
+		m[k] = v * 2
+	}
+}
@@ -0,0 +1,75 @@
+package variable_shadowing
+
+func load(name string) (int, error) { return len(name), nil }
+
+func loopErr(names []string) error {
+	var err error
+	for _, name := range names {
+		_, err := load(name) // want `variable_shadowing`
+		if err != nil {
+			println(err.Error())
+		}
+	}
+	return err
+}
+
+func ifScope(debug bool) int {
+	level := 1
+	if debug {
+		level := 2 // want `variable_shadowing`
+		println(level)
+	}
+	return level
+}
+
+func newAndShadowed(name string) (int, error) {
+	var err error
+	total := 0
+	if name != "" {
+		n, err := load(name) // want `variable_shadowing`
+		if err != nil {
+			println(err.Error())
+		}
+		total += n
+	}
+	return total, err
+}
+
+// 以下不应报告
+
+func assigned(names []string) error {
+	var err error
+	for _, name := range names {
+		_, err = load(name)
+	}
+	return err
+}
+
+func notReadAfter(debug bool) {
+	level := 1
+	println(level)
+	if debug {
+		level := 2
+		println(level)
+	}
+}
+
+func overwrittenAfter(debug bool) int {
+	level := 1
+	println(level)
+	if debug {
+		level := 2
+		println(level)
+	}
+	level = 3
+	return level
+}
+
+func differentType(debug bool) int {
+	level := 1
+	if debug {
+		level := "high"
+		println(level)
+	}
+	return level
+}
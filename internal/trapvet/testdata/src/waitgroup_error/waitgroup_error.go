@@ -0,0 +1,153 @@
+package waitgroup_error
+
+import (
+	"sync"
+	"time"
+)
+
+func tooFewDone() {
+	var wg sync.WaitGroup
+	wg.Add(3) // want `waitgroup_error/count`
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+func doneOutside() {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			println(i)
+		}()
+		wg.Done() // want `waitgroup_error/done_outside`
+	}
+	wg.Wait()
+}
+
+func lateAdd(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		go func() {
+			wg.Add(1) // want `waitgroup_error/late_add`
+			defer wg.Done()
+			println(item)
+		}()
+	}
+	wg.Wait()
+}
+
+func worker(wg *sync.WaitGroup, item string) {
+	wg.Add(1)
+	defer wg.Done()
+	println(item)
+}
+
+func lateAddCall(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		go worker(&wg, item) // want `waitgroup_error/late_add`
+	}
+	wg.Wait()
+}
+
+func byValue(wg sync.WaitGroup) { // want `waitgroup_error/copy`
+	wg.Done()
+}
+
+func passByValue() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go byValue(wg) // want `waitgroup_error/copy`
+	wg.Wait()
+}
+
+// 以下不应报告
+
+func balanced() {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			println(i)
+		}()
+	}
+	wg.Wait()
+}
+
+func addAll() {
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			defer wg.Done()
+			println(i)
+		}()
+	}
+	wg.Wait()
+}
+
+func unknownCount(items []string) {
+	var wg sync.WaitGroup
+	wg.Add(len(items))
+	for _, item := range items {
+		go func() {
+			defer wg.Done()
+			println(item)
+		}()
+	}
+	wg.Wait()
+}
+
+func doneWorker(wg *sync.WaitGroup, item string) {
+	defer wg.Done()
+	println(item)
+}
+
+func pointer(items []string) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go doneWorker(&wg, item)
+	}
+	wg.Wait()
+}
+
+func noGoroutine() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Done()
+	wg.Wait()
+}
+
+func afterFunc() {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	time.AfterFunc(time.Millisecond, func() {
+		wg.Done()
+	})
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+}
+
+type pool struct{}
+
+func (pool) Submit(f func()) { go f() }
+
+func submitted(p pool) {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		p.Submit(func() {
+			defer wg.Done()
+		})
+	}
+	go func() {}()
+	wg.Wait()
+}
@@ -0,0 +1,542 @@
+// Package trapvet 是 examples 中各个陷阱的静态分析器，由 cmd/trapvet 打包成一个命令。
+//
+// 每个分析器以它检查的陷阱 ID 命名（如 defer_order），报告的诊断信息中带有陷阱 ID 和
+// README 中对应章节的链接，Diagnostic.Category 也是陷阱 ID，便于 -json 输出按陷阱汇总。
+// 一个陷阱包含几种不同的错误时，诊断使用“陷阱 ID/子类别”形式的子陷阱 ID（如 waitgroup_error/count）。
+// 分析器只在一个函数（连同其中的函数字面量）内做检查，宁可漏报也不误报：
+// 无法确定的情况（变量逃逸到函数外、被取地址、在其他函数中修改等）都不报告。
+package trapvet
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"go-trap/internal/i18n"
+	"go-trap/internal/readme"
+	"go-trap/registry"
+)
+
+// Analyzers 返回所有分析器，按 registry 中陷阱的顺序排列
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		goroutineClosure,
+		goroutineWait,
+		goroutineLeak,
+		waitgroupError,
+		pointerNil,
+		pointerLocal,
+		pointerReceiver,
+		slicePointer,
+		interfaceNil,
+		interfaceAssertion,
+		interfaceEmpty,
+		interfaceReceiver,
+		channelClose,
+		channelSendClosed,
+		channelReceiveClosed,
+		channelSelectDefault,
+		sliceArray,
+		sliceRangeModify,
+		mapConcurrent,
+		mapNilWrite,
+		mapKeyType,
+		deferOrder,
+		errorHandling,
+		variableShadowing,
+		performancePitfalls,
+	}
+}
+
+// newAnalyzer 创建检查陷阱 trapID 的分析器，分析器名称就是陷阱 ID
+func newAnalyzer(trapID, doc string, run func(*analysis.Pass) (any, error)) *analysis.Analyzer {
+	t, ok := registry.Lookup(trapID)
+	if !ok {
+		panic("trapvet: registry 中没有陷阱 " + trapID)
+	}
+	return &analysis.Analyzer{
+		Name:     trapID,
+		Doc:      i18n.T(doc),
+		URL:      readme.DocURL(t, i18n.Current()),
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      run,
+	}
+}
+
+// report 报告陷阱 trapID（可以是子陷阱 ID），消息按当前语言翻译，并附上陷阱 ID 和 README 链接
+func report(pass *analysis.Pass, trapID string, node ast.Node, fixes []analysis.SuggestedFix, format string, args ...any) {
+	base, _, _ := strings.Cut(trapID, "/")
+	t, _ := registry.Lookup(base)
+	url := readme.DocURL(t, i18n.Current())
+	pass.Report(analysis.Diagnostic{
+		Pos:            node.Pos(),
+		End:            node.End(),
+		Category:       trapID,
+		Message:        i18n.Sprintf("%s（陷阱 %s，见 %s）", i18n.Sprintf(format, args...), trapID, url),
+		URL:            url,
+		SuggestedFixes: fixes,
+	})
+}
+
+// funcBodies 对包中每个函数声明的函数体调用 f，函数字面量包含在所属的函数体中
+func funcBodies(pass *analysis.Pass, f func(decl *ast.FuncDecl)) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		if decl := n.(*ast.FuncDecl); decl.Body != nil {
+			f(decl)
+		}
+	})
+}
+
+// localVar 返回标识符引用的局部变量，不是局部变量（包级变量、字段、函数等）时返回 nil
+func localVar(pass *analysis.Pass, e ast.Expr) *types.Var {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok {
+		v, ok = pass.TypesInfo.Defs[id].(*types.Var)
+	}
+	if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+		return nil
+	}
+	return v
+}
+
+// refersTo 报告 node 中是否引用了变量 v
+func refersTo(pass *analysis.Pass, node ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(id) == v {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// assigns 报告 node 中是否给变量 v 赋值（包括 ++、--、复合赋值和取地址）
+func assigns(pass *analysis.Pass, node ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if localVar(pass, lhs) == v {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if localVar(pass, n.X) == v {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && localVar(pass, n.X) == v {
+				found = true
+			}
+		case *ast.RangeStmt:
+			if localVar(pass, n.Key) == v || n.Value != nil && localVar(pass, n.Value) == v {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isLoop 报告节点是否是 for 或 range 循环
+func isLoop(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.ForStmt, *ast.RangeStmt:
+		return true
+	}
+	return false
+}
+
+// isMethodCall 报告 call 是否调用了包 pkg 中类型 typ 的方法 name（接收者可以是指针）
+func isMethodCall(pass *analysis.Pass, call *ast.CallExpr, pkg, typ, name string) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == pkg && named.Obj().Name() == typ
+}
+
+// isBuiltin 报告 call 是否调用了内置函数 name
+func isBuiltin(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := pass.TypesInfo.Uses[id].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+// callsLock 报告 node 中是否调用了名为 Lock 或 RLock 的方法，用于粗略判断访问是否受锁保护
+func callsLock(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "Lock" || sel.Sel.Name == "RLock") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// inspectStack 与 ast.Inspect 相同，f 同时得到从 root 到 n 的父节点路径（不含 n）
+func inspectStack(root ast.Node, f func(n ast.Node, stack []ast.Node) bool) {
+	var stack []ast.Node
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if !f(n, stack) {
+			return false
+		}
+		stack = append(stack, n)
+		return true
+	})
+}
+
+// goroutineLit 报告 lit 是否是 go 语句直接启动的函数字面量，parent 是 lit 的父节点
+func goroutineLit(lit *ast.FuncLit, parent, grandparent ast.Node) bool {
+	call, ok := parent.(*ast.CallExpr)
+	if !ok || call.Fun != lit {
+		return false
+	}
+	_, ok = grandparent.(*ast.GoStmt)
+	return ok
+}
+
+// fileVersion 返回 pos 所在文件的 Go 版本（如 "go1.21"），未知时返回空字符串
+func fileVersion(pass *analysis.Pass, pos token.Pos) string {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			if v := pass.TypesInfo.FileVersions[f]; v != "" {
+				return v
+			}
+		}
+	}
+	return pass.Pkg.GoVersion()
+}
+
+// typeString 返回相对于当前包的类型名称
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
+
+// identOf 返回 e 去掉括号后的标识符，不是标识符时返回 nil
+func identOf(e ast.Expr) *ast.Ident {
+	id, _ := ast.Unparen(e).(*ast.Ident)
+	return id
+}
+
+// terminates 报告语句块是否以 return、break、continue、goto 或 panic 结束
+func terminates(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	switch s := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := call.Fun.(*ast.Ident)
+		return ok && id.Name == "panic"
+	}
+	return false
+}
+
+// guardsNil 报告语句是否是以 v 为条件的 if 或 switch
+func guardsNil(pass *analysis.Pass, stmt ast.Stmt, v *types.Var) bool {
+	switch s := stmt.(type) {
+	case *ast.IfStmt:
+		return refersTo(pass, s.Cond, v)
+	case *ast.SwitchStmt:
+		return s.Tag == nil || refersTo(pass, s.Tag, v)
+	}
+	return false
+}
+
+// isWaitPoint 报告节点是否会等待其他 goroutine：调用 Wait 方法或接收通道
+func isWaitPoint(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		return ok && sel.Sel.Name == "Wait"
+	case *ast.UnaryExpr:
+		return n.Op == token.ARROW
+	}
+	return false
+}
+
+// inForeverLoop 报告 stack 末尾最近的循环是否是没有条件的 for 循环
+func inForeverLoop(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch s := stack[i].(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.RangeStmt:
+			return false
+		case *ast.ForStmt:
+			return s.Cond == nil
+		}
+	}
+	return false
+}
+
+// chanUse 汇总一个函数中对某个局部通道的使用
+type chanUse struct {
+	make      *ast.CallExpr // 创建通道的 make 调用
+	escapes   bool          // 通道被传给其他函数、赋给其他变量或返回，无法只看这个函数
+	closes    []*ast.CallExpr
+	waitClose ast.Node   // 等待通道关闭的 range 表达式或 v, ok := <-ch
+	recvs     []chanRecv // 单值接收 <-ch
+}
+
+// chanRecv 是一次单值接收
+type chanRecv struct {
+	expr    *ast.UnaryExpr
+	forever bool // 接收位于没有条件的 for 循环中
+}
+
+// localChans 找出函数体中用 make 创建的局部通道，并按用途汇总每次使用
+func localChans(pass *analysis.Pass, body *ast.BlockStmt) map[*types.Var]*chanUse {
+	chans := make(map[*types.Var]*chanUse)
+	ast.Inspect(body, func(n ast.Node) bool {
+		var names, values []ast.Expr
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE || len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			names, values = n.Lhs, n.Rhs
+		case *ast.ValueSpec:
+			if len(n.Names) != len(n.Values) {
+				return true
+			}
+			for _, name := range n.Names {
+				names = append(names, name)
+			}
+			values = n.Values
+		}
+		for i, name := range names {
+			call, ok := ast.Unparen(values[i]).(*ast.CallExpr)
+			if !ok || !isBuiltin(pass, call, "make") {
+				continue
+			}
+			id, ok := name.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok {
+				if _, ok := v.Type().Underlying().(*types.Chan); ok {
+					chans[v] = &chanUse{make: call}
+				}
+			}
+		}
+		return true
+	})
+	if len(chans) == 0 {
+		return nil
+	}
+
+	inspectStack(body, func(n ast.Node, stack []ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, _ := pass.TypesInfo.Uses[id].(*types.Var)
+		use := chans[v]
+		if use == nil {
+			return true
+		}
+		parent := stack[len(stack)-1]
+		switch p := parent.(type) {
+		case *ast.SendStmt:
+			if p.Chan != id {
+				use.escapes = true
+			}
+		case *ast.UnaryExpr:
+			if p.Op != token.ARROW {
+				use.escapes = true
+				break
+			}
+			if as, ok := stack[len(stack)-2].(*ast.AssignStmt); ok && len(as.Lhs) == 2 {
+				use.waitClose = as
+				break
+			}
+			// select 的 case <-done 通常就是在等待关闭信号，不算单值接收
+			if len(stack) >= 3 {
+				if cc, ok := stack[len(stack)-3].(*ast.CommClause); ok && cc.Comm == stack[len(stack)-2] {
+					break
+				}
+			}
+			use.recvs = append(use.recvs, chanRecv{expr: p, forever: inForeverLoop(stack)})
+		case *ast.RangeStmt:
+			if p.X == id {
+				use.waitClose = p.X
+			} else {
+				use.escapes = true
+			}
+		case *ast.CallExpr:
+			switch {
+			case isBuiltin(pass, p, "close"):
+				use.closes = append(use.closes, p)
+			case isBuiltin(pass, p, "len"), isBuiltin(pass, p, "cap"):
+			default:
+				use.escapes = true
+			}
+		case *ast.BinaryExpr:
+			// ch == nil 之类的比较
+		default:
+			use.escapes = true
+		}
+		return true
+	})
+	return chans
+}
+
+// fileOf 返回 pos 所在的文件
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			return f
+		}
+	}
+	return nil
+}
+
+// fileQualifier 按文件中的导入名称限定其他包的类型名
+func fileQualifier(pass *analysis.Pass, file *ast.File) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pass.Pkg {
+			return ""
+		}
+		for _, spec := range file.Imports {
+			if path, _ := strconv.Unquote(spec.Path.Value); path == p.Path() && spec.Name != nil {
+				return spec.Name.Name
+			}
+		}
+		return p.Name()
+	}
+}
+
+// sourceText 返回节点在源文件中的原文
+func sourceText(pass *analysis.Pass, n ast.Node) string {
+	tf := pass.Fset.File(n.Pos())
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return ""
+	}
+	return string(src[tf.Offset(n.Pos()):tf.Offset(n.End())])
+}
+
+// lineIndent 返回 pos 所在行开头的空白，用于在 pos 之前插入新的一行
+func lineIndent(pass *analysis.Pass, pos token.Pos) string {
+	tf := pass.Fset.File(pos)
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return ""
+	}
+	line := src[tf.Offset(tf.LineStart(tf.Line(pos))):tf.Offset(pos)]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// startsLine 报告 pos 之前是否只有缩进
+func startsLine(pass *analysis.Pass, pos token.Pos) bool {
+	tf := pass.Fset.File(pos)
+	return tf.Offset(tf.LineStart(tf.Line(pos)))+len(lineIndent(pass, pos)) == tf.Offset(pos)
+}
+
+// afterLine 返回在 pos 所在行之后插入一行 text 的修改，行尾的注释留在原来的行上
+func afterLine(pass *analysis.Pass, pos token.Pos, text string) analysis.TextEdit {
+	tf := pass.Fset.File(pos)
+	if line := tf.Line(pos); line < tf.LineCount() {
+		start := tf.LineStart(line + 1)
+		return analysis.TextEdit{Pos: start, End: start, NewText: []byte(text + "\n")}
+	}
+	return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte("\n" + text)}
+}
+
+// importStd 返回文件中标准库包 path 的名称，文件没有导入这个包时同时返回添加导入的修改
+func importStd(pass *analysis.Pass, file *ast.File, path string) (string, *analysis.TextEdit) {
+	quoted := strconv.Quote(path)
+	name := path[strings.LastIndex(path, "/")+1:]
+	for _, spec := range file.Imports {
+		if spec.Path.Value == quoted {
+			if spec.Name != nil && spec.Name.Name != "_" && spec.Name.Name != "." {
+				return spec.Name.Name, nil
+			}
+			if spec.Name == nil {
+				return name, nil
+			}
+		}
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || len(gen.Specs) == 0 {
+			continue
+		}
+		if !gen.Lparen.IsValid() {
+			// import "a" 改为带括号的形式，标准库和其他包之间空一行
+			spec := gen.Specs[0].(*ast.ImportSpec)
+			text := "import (\n\t" + quoted + "\n\t" + sourceText(pass, spec) + "\n)"
+			if other, _ := strconv.Unquote(spec.Path.Value); !isStdImport(pass, other) {
+				text = "import (\n\t" + quoted + "\n\n\t" + sourceText(pass, spec) + "\n)"
+			} else if other < path {
+				text = "import (\n\t" + sourceText(pass, spec) + "\n\t" + quoted + "\n)"
+			}
+			return name, &analysis.TextEdit{Pos: gen.Pos(), End: gen.End(), NewText: []byte(text)}
+		}
+		// 插入到第一组导入中按字母顺序的位置
+		var prev ast.Spec
+		for _, s := range gen.Specs {
+			spec := s.(*ast.ImportSpec)
+			if prev != nil && pass.Fset.Position(spec.Pos()).Line > pass.Fset.Position(prev.End()).Line+1 {
+				break
+			}
+			if spec.Path.Value > quoted {
+				return name, &analysis.TextEdit{Pos: spec.Pos(), End: spec.Pos(), NewText: []byte(quoted + "\n\t")}
+			}
+			prev = spec
+		}
+		return name, &analysis.TextEdit{Pos: prev.End(), End: prev.End(), NewText: []byte("\n\t" + quoted)}
+	}
+	return name, &analysis.TextEdit{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + quoted)}
+}
+
+// isStdImport 报告导入路径是否属于标准库：第一段不含 "."，也不是当前模块
+func isStdImport(pass *analysis.Pass, path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	module, _, _ := strings.Cut(pass.Pkg.Path(), "/")
+	return !strings.Contains(first, ".") && first != module
+}
@@ -0,0 +1,40 @@
+package trapvet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"go-trap/internal/i18n"
+)
+
+// 诊断信息按中文原文匹配，// want 中写子陷阱 ID
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.Zh)
+	os.Exit(m.Run())
+}
+
+// TestAnalyzers 用 testdata/src/<分析器名称> 中的代码测试每个分析器，
+// 目录中有 .golden 文件时同时检查建议修复的结果
+func TestAnalyzers(t *testing.T) {
+	testdata := analysistest.TestData()
+	for _, a := range Analyzers() {
+		t.Run(a.Name, func(t *testing.T) {
+			dir := filepath.Join(testdata, "src", a.Name)
+			if _, err := os.Stat(dir); err != nil {
+				t.Fatalf("分析器没有测试数据: %v", err)
+			}
+			goldens, err := filepath.Glob(filepath.Join(dir, "*.golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(goldens) > 0 {
+				analysistest.RunWithSuggestedFixes(t, testdata, a, a.Name)
+			} else {
+				analysistest.Run(t, testdata, a, a.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"go-trap/internal/i18n"
+)
+
+var variableShadowing = newAnalyzer("variable_shadowing",
+	"检查内层作用域中用 := 声明、遮蔽了外层同名同类型变量的变量，且外层变量在内层作用域结束后还会被读取",
+	runVariableShadowing)
+
+func runVariableShadowing(pass *analysis.Pass) (any, error) {
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			as, ok := n.(*ast.AssignStmt)
+			if !ok || as.Tok != token.DEFINE {
+				return true
+			}
+			var names []string
+			var first ast.Node
+			fixable := true
+			for _, lhs := range as.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name == "_" {
+					continue
+				}
+				inner, ok := pass.TypesInfo.Defs[id].(*types.Var)
+				if !ok {
+					continue // 复用了同一作用域中已有的变量
+				}
+				outer := shadowedVar(decl, inner)
+				if outer == nil {
+					fixable = false // 改为 = 后这个变量没有声明
+					continue
+				}
+				if !types.Identical(inner.Type(), outer.Type()) {
+					fixable = false
+					continue
+				}
+				if !readAfter(pass, decl.Body, outer, inner.Parent().End()) {
+					continue
+				}
+				names = append(names, id.Name)
+				if first == nil {
+					first = id
+				}
+			}
+			if first == nil {
+				return true
+			}
+			var fixes []analysis.SuggestedFix
+			if fixable {
+				fixes = []analysis.SuggestedFix{{
+					Message: i18n.T("把 := 改为 =，给外层的变量赋值"),
+					TextEdits: []analysis.TextEdit{{
+						Pos:     as.TokPos,
+						End:     as.TokPos + token.Pos(len(":=")),
+						NewText: []byte("="),
+					}},
+				}}
+			}
+			list := strings.Join(names, i18n.T("、"))
+			report(pass, "variable_shadowing", first, fixes,
+				"这里的 := 声明了新的 %s，遮蔽了外层的同名变量；内层作用域结束后读取的仍是外层没有被更新的 %s。要更新外层变量时把 := 改为 =，否则换一个变量名",
+				list, list)
+			return true
+		})
+	})
+	return nil, nil
+}
+
+// shadowedVar 返回被 inner 遮蔽的、在同一个函数中声明的外层局部变量，没有时返回 nil
+func shadowedVar(decl *ast.FuncDecl, inner *types.Var) *types.Var {
+	scope := inner.Parent()
+	if scope == nil || scope.Parent() == nil {
+		return nil
+	}
+	_, obj := scope.Parent().LookupParent(inner.Name(), inner.Pos())
+	outer, ok := obj.(*types.Var)
+	if !ok || outer.IsField() || outer.Pos() < decl.Pos() || outer.Pos() >= decl.End() {
+		return nil
+	}
+	return outer
+}
+
+// readAfter 报告 after 之后第一次出现的变量 v 是否是读取，而不是重新赋值
+func readAfter(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var, after token.Pos) bool {
+	var next *ast.Ident
+	assigned := make(map[*ast.Ident]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			reads := false
+			for _, rhs := range n.Rhs {
+				reads = reads || refersTo(pass, rhs, v)
+			}
+			// x = x + 1 这样的赋值先读取了 x；v, err := f() 重新声明时也是给 v 赋值
+			if (n.Tok == token.ASSIGN || n.Tok == token.DEFINE) && !reads {
+				for _, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						assigned[id] = true
+					}
+				}
+			}
+		case *ast.Ident:
+			if n.Pos() > after && pass.TypesInfo.Uses[n] == v && (next == nil || n.Pos() < next.Pos()) {
+				next = n
+			}
+		}
+		return true
+	})
+	return next != nil && !assigned[next]
+}
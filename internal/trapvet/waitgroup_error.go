@@ -0,0 +1,332 @@
+package trapvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var waitgroupError = newAnalyzer("waitgroup_error",
+	"检查 sync.WaitGroup 的误用：Add 与 Done 次数不匹配、在启动 goroutine 的函数中直接调用 Done、在 goroutine 内部才调用 Add、按值传递 WaitGroup",
+	runWaitgroupError)
+
+// waitgroup_error 的子陷阱
+const (
+	wgCount       = "waitgroup_error/count"        // Add 的总数与 Done 的次数不相等
+	wgDoneOutside = "waitgroup_error/done_outside" // Done 不在 goroutine 中调用
+	wgLateAdd     = "waitgroup_error/late_add"     // Add 在 goroutine 内部调用
+	wgCopy        = "waitgroup_error/copy"         // WaitGroup 按值传递
+)
+
+func runWaitgroupError(pass *analysis.Pass) (any, error) {
+	addParams := waitGroupAddParams(pass)
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		checkWaitGroupCopy(pass, decl)
+		checkLateAddCalls(pass, decl, addParams)
+		for _, v := range localWaitGroups(pass, decl.Body) {
+			checkWaitGroupUse(pass, decl, v)
+		}
+	})
+	return nil, nil
+}
+
+// isWaitGroup 报告 t 是否是 sync.WaitGroup（不是指针）
+func isWaitGroup(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "sync" && named.Obj().Name() == "WaitGroup"
+}
+
+// waitGroupMethod 返回 call 调用的 WaitGroup 方法名和接收者表达式，不是 WaitGroup 方法时返回空字符串
+func waitGroupMethod(pass *analysis.Pass, call *ast.CallExpr) (string, ast.Expr) {
+	for _, name := range []string{"Add", "Done", "Wait"} {
+		if isMethodCall(pass, call, "sync", "WaitGroup", name) {
+			return name, ast.Unparen(call.Fun).(*ast.SelectorExpr).X
+		}
+	}
+	return "", nil
+}
+
+// checkWaitGroupCopy 报告按值传递的 WaitGroup：类型为 sync.WaitGroup 的参数，以及传给函数的 WaitGroup 值
+func checkWaitGroupCopy(pass *analysis.Pass, decl *ast.FuncDecl) {
+	params := func(ft *ast.FuncType) {
+		for _, field := range ft.Params.List {
+			if !isWaitGroup(pass.TypesInfo.TypeOf(field.Type)) {
+				continue
+			}
+			for _, name := range field.Names {
+				report(pass, wgCopy, name, nil,
+					"参数 %s 按值传递 sync.WaitGroup，函数中的 Add、Done 作用在副本上，调用方的 Wait 看不到；改为 *sync.WaitGroup",
+					name.Name)
+			}
+		}
+	}
+	params(decl.Type)
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			params(n.Type)
+		case *ast.CallExpr:
+			if _, ok := pass.TypesInfo.TypeOf(n.Fun).(*types.Signature); !ok {
+				return true // 类型转换
+			}
+			for _, arg := range n.Args {
+				if _, ok := ast.Unparen(arg).(*ast.CompositeLit); ok {
+					continue
+				}
+				if isWaitGroup(pass.TypesInfo.TypeOf(arg)) {
+					report(pass, wgCopy, arg, nil,
+						"%s 按值传给了函数，传入的是 WaitGroup 的副本，在副本上调用 Done 不会让这里的 Wait 返回；传 &%s",
+						types.ExprString(arg), types.ExprString(arg))
+				}
+			}
+		}
+		return true
+	})
+}
+
+// waitGroupAddParams 找出包中在函数体内对 *sync.WaitGroup 参数调用 Add 的函数，返回参数的位置
+func waitGroupAddParams(pass *analysis.Pass) map[*types.Func][]int {
+	params := make(map[*types.Func][]int)
+	funcBodies(pass, func(decl *ast.FuncDecl) {
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		sig := fn.Type().(*types.Signature)
+		for i := 0; i < sig.Params().Len(); i++ {
+			p := sig.Params().At(i)
+			ptr, ok := p.Type().(*types.Pointer)
+			if !ok || !isWaitGroup(ptr.Elem()) {
+				continue
+			}
+			found := false
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				if _, ok := n.(*ast.FuncLit); ok {
+					return false
+				}
+				if call, ok := n.(*ast.CallExpr); ok {
+					if name, x := waitGroupMethod(pass, call); name == "Add" && localVar(pass, x) == p {
+						found = true
+					}
+				}
+				return !found
+			})
+			if found {
+				params[fn] = append(params[fn], i)
+			}
+		}
+	})
+	return params
+}
+
+// checkLateAddCalls 报告 go 语句启动的函数在 goroutine 内部才对传入的 WaitGroup 调用 Add
+func checkLateAddCalls(pass *analysis.Pass, decl *ast.FuncDecl, addParams map[*types.Func][]int) {
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		g, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		id, ok := ast.Unparen(g.Call.Fun).(*ast.Ident)
+		if !ok {
+			return true
+		}
+		fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+		if !ok {
+			return true
+		}
+		for _, i := range addParams[fn] {
+			if i < len(g.Call.Args) {
+				report(pass, wgLateAdd, g.Call.Args[i], nil,
+					"%s 在新启动的 goroutine 中才调用 WaitGroup 的 Add，Wait 可能在 Add 之前执行并立即返回；在 go 语句之前调用 Add",
+					fn.Name())
+			}
+		}
+		return true
+	})
+}
+
+// localWaitGroups 返回函数体中声明的 sync.WaitGroup 局部变量
+func localWaitGroups(pass *analysis.Pass, body *ast.BlockStmt) []*types.Var {
+	var vars []*types.Var
+	ast.Inspect(body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok && isWaitGroup(v.Type()) {
+				vars = append(vars, v)
+			}
+		}
+		return true
+	})
+	return vars
+}
+
+// wgCall 是对局部 WaitGroup 的一次方法调用
+type wgCall struct {
+	call      *ast.CallExpr
+	method    string
+	goroutine bool // 在 go 语句启动的函数字面量中
+	async     bool // 在没有被立即调用的函数字面量中，如传给 time.AfterFunc 的回调，可能在别处异步执行
+	times     int  // 考虑所在循环后的执行次数，-1 表示无法确定
+}
+
+// checkWaitGroupUse 检查函数中对局部 WaitGroup v 的 Add、Done 调用
+func checkWaitGroupUse(pass *analysis.Pass, decl *ast.FuncDecl, v *types.Var) {
+	var calls []wgCall
+	escapes, spawns := false, false
+	inspectStack(decl.Body, func(n ast.Node, stack []ast.Node) bool {
+		if _, ok := n.(*ast.GoStmt); ok {
+			spawns = true
+		}
+		id, ok := n.(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[id] != v {
+			return true
+		}
+		parent := stack[len(stack)-1]
+		sel, ok := parent.(*ast.SelectorExpr)
+		if !ok || sel.X != id || len(stack) < 2 {
+			escapes = true
+			return true
+		}
+		call, ok := stack[len(stack)-2].(*ast.CallExpr)
+		if !ok || call.Fun != sel {
+			escapes = true
+			return true
+		}
+		method, _ := waitGroupMethod(pass, call)
+		c := wgCall{call: call, method: method, times: executions(stack)}
+		for i := len(stack) - 1; i >= 2; i-- {
+			lit, ok := stack[i].(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+			if goroutineLit(lit, stack[i-1], stack[i-2]) {
+				c.goroutine = true
+				break
+			}
+			if call, ok := stack[i-1].(*ast.CallExpr); !ok || call.Fun != lit {
+				c.async = true
+			}
+		}
+		calls = append(calls, c)
+		return true
+	})
+
+	waits := false
+	for _, c := range calls {
+		switch {
+		case c.method == "Wait":
+			waits = true
+		case c.method == "Add" && c.goroutine:
+			report(pass, wgLateAdd, c.call, nil,
+				"%s.Add 在 goroutine 内部调用，Wait 可能在 Add 之前执行并立即返回；在 go 语句之前调用 %s.Add",
+				v.Name(), v.Name())
+		case c.method == "Done" && !c.goroutine && !c.async && spawns:
+			report(pass, wgDoneOutside, c.call, nil,
+				"%s.Done 没有在 goroutine 中调用，计数在 goroutine 完成之前就减少了，Wait 可能提前返回；在 goroutine 中调用 defer %s.Done()",
+				v.Name(), v.Name())
+		}
+	}
+	// 只有所有调用都在这个函数里、次数都能确定时才比较 Add 和 Done
+	if escapes || !waits {
+		return
+	}
+	adds, dones := 0, 0
+	var first *ast.CallExpr
+	for _, c := range calls {
+		if c.times < 0 {
+			return
+		}
+		switch c.method {
+		case "Add":
+			tv := pass.TypesInfo.Types[c.call.Args[0]]
+			if tv.Value == nil {
+				return
+			}
+			n, ok := constant.Int64Val(tv.Value)
+			if !ok {
+				return
+			}
+			adds += int(n) * c.times
+			if first == nil {
+				first = c.call
+			}
+		case "Done":
+			dones += c.times
+		}
+	}
+	if first != nil && adds != dones {
+		report(pass, wgCount, first, nil,
+			"%s.Add 一共增加了 %d，%s.Done 一共调用了 %d 次：Done 少于 Add 时 Wait 永远阻塞，多于 Add 时 panic: sync: negative WaitGroup counter；让每次 Add 对应一次 Done",
+			v.Name(), adds, v.Name(), dones)
+	}
+}
+
+// executions 返回 stack 末尾的节点执行的次数：不在循环中为 1，在 for i := a; i < b; i++ 这样次数固定的循环中相乘；
+// 在条件分支、select、次数不固定的循环或不是立即调用的函数字面量中返回 -1
+func executions(stack []ast.Node) int {
+	times := 1
+	for i, n := range stack {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if call, ok := stack[i-1].(*ast.CallExpr); !ok || call.Fun != n {
+				return -1
+			}
+		case *ast.IfStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			return -1
+		case *ast.RangeStmt:
+			return -1
+		case *ast.ForStmt:
+			// 只有在循环体中才重复执行，Init 等部分只执行一次
+			if i+1 < len(stack) && stack[i+1] == n.Body {
+				k := loopCount(n)
+				if k < 0 {
+					return -1
+				}
+				times *= k
+			}
+		}
+	}
+	return times
+}
+
+// loopCount 返回 for i := a; i < b; i++ 形式的循环的次数，a、b 都是整数常量字面量；其他形式返回 -1
+func loopCount(loop *ast.ForStmt) int {
+	init, ok := loop.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+		return -1
+	}
+	cond, ok := loop.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.LSS {
+		return -1
+	}
+	post, ok := loop.Post.(*ast.IncDecStmt)
+	if !ok || post.Tok != token.INC {
+		return -1
+	}
+	i, ok := init.Lhs[0].(*ast.Ident)
+	if !ok || !sameIdent(cond.X, i) || !sameIdent(post.X, i) {
+		return -1
+	}
+	a, ok1 := intLit(init.Rhs[0])
+	b, ok2 := intLit(cond.Y)
+	if !ok1 || !ok2 || b < a {
+		return -1
+	}
+	return b - a
+}
+
+func sameIdent(e ast.Expr, id *ast.Ident) bool {
+	x, ok := e.(*ast.Ident)
+	return ok && x.Name == id.Name
+}
+
+func intLit(e ast.Expr) (int, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	n, ok := constant.Int64Val(constant.MakeFromLiteral(lit.Value, lit.Kind, 0))
+	return int(n), ok
+}
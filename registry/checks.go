@@ -0,0 +1,54 @@
+package registry
+
+// CrashKind 是错误示例崩溃的方式
+type CrashKind string
+
+const (
+	// Panic 表示普通的 panic，stderr 中以 "panic: " 开头
+	Panic CrashKind = "panic"
+	// Fatal 表示运行时的 fatal error（如死锁、并发读写 map），无法被 recover
+	Fatal CrashKind = "fatal"
+)
+
+// Crash 描述一个运行就会崩溃的错误示例，以及期望的崩溃结果。
+// 这些函数不会在完整演示中调用，gotrap crash 会在子进程中单独运行它们。
+type Crash struct {
+	Func     string    // 会崩溃的示例函数，即示例命令的 -run 参数
+	Kind     CrashKind // 崩溃方式
+	Message  string    // "panic: " 或 "fatal error: " 之后应包含的内容
+	ExitCode int       // 期望的进程退出码，Go 运行时崩溃时为 2
+}
+
+// RaceCheck 描述竞态检测模式下对一个示例函数的期望
+type RaceCheck struct {
+	Func string // 示例函数，即示例命令的 -run 参数
+	Racy bool   // 是否应该被竞态检测器报告数据竞争
+}
+
+// VetCheck 描述 trapvet 对一个示例函数的期望
+type VetCheck struct {
+	Func    string // 示例函数，方法写作 "Type.Method"
+	Flagged bool   // 是否应该被陷阱对应的分析器报告
+
+	// Category 是期望的诊断类别，即子陷阱 ID（如 "waitgroup_error/count"）；
+	// 为空时不检查类别
+	Category string
+}
+
+// BenchMetric 是 go test -benchmem 输出的一项指标
+type BenchMetric string
+
+const (
+	NsPerOp     BenchMetric = "ns/op"
+	BytesPerOp  BenchMetric = "B/op"
+	AllocsPerOp BenchMetric = "allocs/op"
+)
+
+// BenchPair 把错误写法和对应的正确写法的基准测试配成一对，
+// 基准测试与示例在同一个包中，名称为 "Benchmark" 加上示例函数名
+type BenchPair struct {
+	Wrong    string      // 错误写法的示例函数
+	Correct  string      // 正确写法的示例函数
+	Metric   BenchMetric // 用来检验文档中所说的改进的指标
+	MinRatio float64     // 错误写法与正确写法在 Metric 上的比值至少应为多少，0 表示只报告比值不检查
+}
@@ -0,0 +1,26 @@
+package registry
+
+// OutputMode 指定如何处理示例输出中不确定的部分
+type OutputMode int
+
+const (
+	// Sorted 表示段内各行的顺序不固定，排序后按多重集合比较
+	Sorted OutputMode = iota
+	// Set 表示段内各行的顺序和重复次数都不固定，去重排序后按集合比较
+	Set
+	// Scrub 把段内匹配 Pattern 的内容替换为 Replace，用于屏蔽不确定的数值
+	Scrub
+	// Float 表示匹配 Pattern 的行可能出现在输出的任意位置（例如没有被等待的 goroutine 的打印），
+	// 这些行会被移到输出末尾，排序后按多重集合比较
+	Float
+)
+
+// OutputRule 描述示例输出中一处不确定的内容。
+// 输出按空行分段，段落从 0 开始编号，第 0 段是 "=== 陷阱示例 ===" 标题。
+// 每段的第一行是该段的标题（如 "错误示例："），规则只作用于标题之后的行。
+type OutputRule struct {
+	Mode    OutputMode
+	Section int    // 规则作用的段落；Float 作用于整个输出，忽略此字段
+	Pattern string // Scrub 和 Float 使用的正则表达式
+	Replace string // Scrub 的替换内容
+}
@@ -0,0 +1,178 @@
+// Package registry 是所有 Go 陷阱示例的元数据中心。
+//
+// 每个陷阱都有稳定的 ID（与示例文件名一致）、分类、严重程度、受影响的 Go 版本、
+// README 锚点，以及示例中错误写法和正确写法对应的函数。
+// 运行器、检查工具和文档生成都从这里读取，不再各自维护一份列表。
+package registry
+
+import (
+	"go/version"
+	"path"
+	"slices"
+)
+
+// Category 是陷阱的分类，与 README.md 的章节对应
+type Category string
+
+const (
+	Goroutine Category = "goroutine"
+	Pointer   Category = "pointer"
+	Interface Category = "interface"
+	Channel   Category = "channel"
+	Other     Category = "other"
+)
+
+// Categories 按 README.md 的章节顺序返回所有分类
+func Categories() []Category {
+	return []Category{Goroutine, Pointer, Interface, Channel, Other}
+}
+
+// Title 返回分类在 README.md 中的章节标题
+func (c Category) Title() string {
+	switch c {
+	case Goroutine:
+		return "协程（Goroutines）陷阱"
+	case Pointer:
+		return "指针（Pointers）陷阱"
+	case Interface:
+		return "接口（Interfaces）陷阱"
+	case Channel:
+		return "通道（Channels）陷阱"
+	default:
+		return "其他常见陷阱"
+	}
+}
+
+// Severity 表示踩中陷阱的后果有多严重
+type Severity string
+
+const (
+	Low      Severity = "low"      // 性能或可读性问题，或者只是容易误解的概念
+	Medium   Severity = "medium"   // 结果错误、行为与预期不符
+	High     Severity = "high"     // panic、死锁或 goroutine 泄漏
+	Critical Severity = "critical" // 无法 recover 的 fatal error
+)
+
+// GoVersions 描述受影响的 Go 版本范围，版本号形如 "1.22"；空字符串表示不限制
+type GoVersions struct {
+	From   string // 从该版本开始受影响（包含）
+	Before string // 到该版本为止不再受影响（不包含）
+}
+
+// AllVersions 表示所有 Go 版本都受影响
+var AllVersions = GoVersions{}
+
+// Affects 报告给定的 Go 版本（如 "1.21" 或 "go1.22.1"）是否受影响
+func (v GoVersions) Affects(goVersion string) bool {
+	gv := normalize(goVersion)
+	if v.From != "" && version.Compare(gv, normalize(v.From)) < 0 {
+		return false
+	}
+	if v.Before != "" && version.Compare(gv, normalize(v.Before)) >= 0 {
+		return false
+	}
+	return true
+}
+
+func (v GoVersions) String() string {
+	switch {
+	case v.From == "" && v.Before == "":
+		return "all"
+	case v.From == "":
+		return "< " + v.Before
+	case v.Before == "":
+		return ">= " + v.From
+	default:
+		return ">= " + v.From + ", < " + v.Before
+	}
+}
+
+func normalize(v string) string {
+	if len(v) >= 2 && v[:2] == "go" {
+		return v
+	}
+	return "go" + v
+}
+
+// Trap 描述一个陷阱示例
+type Trap struct {
+	ID         string     // 稳定 ID，与示例文件名一致（不含 .go）
+	Title      string     // 陷阱标题，即示例文件头部的“陷阱：”注释
+	Problem    string     // 问题描述，即示例文件头部的“问题：”注释
+	Category   Category   // 分类
+	Severity   Severity   // 严重程度
+	GoVersions GoVersions // 受影响的 Go 版本
+	Anchor     string     // README.md 中对应章节的锚点（不含 #）
+	Source     string     // 示例源文件，相对于仓库根目录
+	Wrong      []string   // 演示错误写法的导出函数，方法写作 "Type.Method"
+	Correct    []string   // 演示正确写法的导出函数
+
+	// Notes 是 README.md 中问题描述之后的补充说明，每项是一段 Markdown
+	Notes []string
+
+	// Crashes 列出运行就会崩溃的错误示例及期望的崩溃结果
+	Crashes []Crash
+
+	// Races 列出在竞态检测模式（-race）下要检查的示例函数，
+	// 错误写法应该报告数据竞争，正确写法不应该
+	Races []RaceCheck
+
+	// Vet 列出要用 trapvet 中该陷阱的分析器检查的示例函数，
+	// 错误写法应该被报告，正确写法不应该
+	Vet []VetCheck
+
+	// Benches 列出要用基准测试检验的错误写法和正确写法，
+	// gotrap bench 计算两者的比值，并检查是否达到文档中所说的改进
+	Benches []BenchPair
+
+	// Output 描述示例输出中不确定的部分，golden 比较前按这些规则规范化；
+	// 为空表示输出是完全确定的
+	Output []OutputRule
+}
+
+// Package 返回示例所在包的导入路径，如 "go-trap/examples/goroutines"
+func (t Trap) Package() string {
+	return Module + "/" + path.Dir(t.Source)
+}
+
+// Main 返回运行该示例的命令包，相对于仓库根目录
+func (t Trap) Main() string {
+	return "./examples/cmd/" + t.ID
+}
+
+// Module 是仓库的模块路径
+const Module = "go-trap"
+
+// DocURL 返回陷阱在 README.md 中的链接
+func (t Trap) DocURL() string {
+	return DocBase + "#" + t.Anchor
+}
+
+// DocBase 是 README.md 的在线地址
+const DocBase = "https://github.com/matyle/golang-trap/blob/main/README.md"
+
+// All 按 README.md 的顺序返回所有陷阱
+func All() []Trap {
+	return slices.Clone(traps)
+}
+
+// Lookup 按 ID 查找陷阱
+func Lookup(id string) (Trap, bool) {
+	for _, t := range traps {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Trap{}, false
+}
+
+// ByCategory 返回某个分类下的所有陷阱
+func ByCategory(c Category) []Trap {
+	var out []Trap
+	for _, t := range traps {
+		if t.Category == c {
+			out = append(out, t)
+		}
+	}
+	return out
+}
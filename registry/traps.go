@@ -0,0 +1,589 @@
+package registry
+
+// traps 按 README.md 的目录顺序列出所有陷阱
+var traps = []Trap{
+	// 1. 协程（Goroutines）陷阱
+	{
+		ID:         "goroutine_closure",
+		Title:      "闭包变量捕获问题",
+		Problem:    "在循环中使用 goroutine 时，所有 goroutine 可能共享同一个变量",
+		Category:   Goroutine,
+		Severity:   Medium,
+		GoVersions: GoVersions{Before: "1.22"}, // Go 1.22 起每次迭代都有独立的循环变量
+		Anchor:     "11-闭包变量捕获问题",
+		Source:     "examples/goroutines/goroutine_closure.go",
+		Wrong:      []string{"ClosureWrongWay", "ClosureWrongWay2"},
+		Correct:    []string{"ClosureCorrectWay", "ClosureCorrectWay2"},
+		Notes: []string{
+			"**Go 1.22 的变化**：从 Go 1.22 开始（go.mod 中的 go 版本 >= 1.22，本仓库满足这个条件），" +
+				"`for` 循环的变量每次迭代都是新变量，`ClosureWrongWay` 中的 goroutine 会打印各自的 i，" +
+				"`ClosureCorrectWay2` 中的 `i := i` 也不再需要。" +
+				"但循环外声明的变量仍然被所有 goroutine 共享，`ClosureWrongWay2` 在任何版本下都有数据竞争。",
+		},
+		Races: []RaceCheck{
+			// go.mod 声明了 Go 1.22，循环变量每次迭代独立，ClosureWrongWay 不再有数据竞争
+			{Func: "ClosureWrongWay", Racy: false},
+			{Func: "ClosureWrongWay2", Racy: true},
+			{Func: "ClosureCorrectWay", Racy: false},
+			{Func: "ClosureCorrectWay2", Racy: false},
+		},
+		Output: []OutputRule{
+			{Mode: Sorted, Section: 1},
+			{Mode: Sorted, Section: 2},
+		},
+		Vet: []VetCheck{
+			// go.mod 的 Go 版本 >= 1.22，捕获循环变量不再是问题，分析器只在低版本下报告
+			{Func: "ClosureWrongWay", Flagged: false},
+			{Func: "ClosureWrongWay2", Flagged: true},
+			{Func: "ClosureCorrectWay", Flagged: false},
+			{Func: "ClosureCorrectWay2", Flagged: false},
+		},
+	},
+	{
+		ID:         "goroutine_wait",
+		Title:      "未等待 Goroutine 完成",
+		Problem:    "主程序在 goroutine 完成前退出，导致 goroutine 被强制终止",
+		Category:   Goroutine,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "12-未等待-goroutine-完成",
+		Source:     "examples/goroutines/goroutine_wait.go",
+		Wrong:      []string{"WaitWrongWay"},
+		Correct:    []string{"WaitCorrectWay"},
+		Output: []OutputRule{
+			// 错误示例中没有被等待的 goroutine 会在正确示例运行期间打印，也可能来不及打印
+			{Mode: Set, Section: 2},
+		},
+		Vet: []VetCheck{
+			{Func: "WaitWrongWay", Flagged: true},
+			{Func: "WaitCorrectWay", Flagged: false},
+		},
+	},
+	{
+		ID:         "goroutine_leak",
+		Title:      "Goroutine 泄漏",
+		Problem:    "goroutine 因为通道阻塞而永远无法退出，造成内存泄漏",
+		Category:   Goroutine,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "13-goroutine-泄漏",
+		Source:     "examples/goroutines/goroutine_leak.go",
+		Wrong:      []string{"LeakWrongWay", "LeakWrongWay2"},
+		Correct:    []string{"LeakCorrectWay", "LeakCorrectWay2", "LeakCorrectWay3", "LeakCorrectWay4"},
+		Vet: []VetCheck{
+			{Func: "LeakWrongWay", Flagged: true, Category: "goroutine_leak/unmatched"},
+			{Func: "LeakWrongWay2", Flagged: true, Category: "goroutine_leak/abandoned_sender"},
+			{Func: "LeakCorrectWay", Flagged: false},
+			{Func: "LeakCorrectWay2", Flagged: false},
+			{Func: "LeakCorrectWay3", Flagged: false},
+			{Func: "LeakCorrectWay4", Flagged: false},
+		},
+	},
+	{
+		ID:         "waitgroup_error",
+		Title:      "WaitGroup 使用错误",
+		Problem:    "WaitGroup 使用不当导致死锁或 goroutine 泄漏",
+		Category:   Goroutine,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "14-waitgroup-使用错误",
+		Source:     "examples/goroutines/waitgroup_error.go",
+		Wrong:      []string{"WaitGroupTrap1", "WaitGroupTrap2", "WaitGroupTrap3"},
+		Correct:    []string{"WaitGroupCorrectWay", "WaitGroupCorrectWay2", "RunWithWaitGroup"},
+		Crashes: []Crash{
+			{Func: "WaitGroupTrap1", Kind: Fatal, Message: "all goroutines are asleep - deadlock!", ExitCode: 2},
+		},
+		Vet: []VetCheck{
+			{Func: "WaitGroupTrap1", Flagged: true, Category: "waitgroup_error/count"},
+			{Func: "WaitGroupTrap2", Flagged: true, Category: "waitgroup_error/done_outside"},
+			{Func: "WaitGroupTrap3", Flagged: true, Category: "waitgroup_error/late_add"},
+			{Func: "WaitGroupCorrectWay", Flagged: false},
+			{Func: "WaitGroupCorrectWay2", Flagged: false},
+			{Func: "RunWithWaitGroup", Flagged: false},
+		},
+		Output: []OutputRule{
+			// 陷阱2 中没有被等待的 goroutine 可能在陷阱2 或陷阱3 的段落里打印
+			{Mode: Float, Pattern: `^(Goroutine 执行|goroutine running)$`},
+			{Mode: Sorted, Section: 4},
+		},
+	},
+
+	// 2. 指针（Pointers）陷阱
+	{
+		ID:         "pointer_nil",
+		Title:      "Nil 指针解引用",
+		Problem:    "在使用指针前未检查是否为 nil，导致程序 panic",
+		Category:   Pointer,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "21-nil-指针解引用",
+		Source:     "examples/pointers/pointer_nil.go",
+		Wrong:      []string{"NilWrongWay", "Person.GetName"},
+		Correct:    []string{"NilCorrectWay", "SafeGetName"},
+		Crashes: []Crash{
+			{Func: "NilWrongWay", Kind: Panic, Message: "invalid memory address or nil pointer dereference", ExitCode: 2},
+		},
+		Vet: []VetCheck{
+			{Func: "NilWrongWay", Flagged: true},
+			// 接收者是否为 nil 取决于调用方，只看方法本身无法判断
+			{Func: "Person.GetName", Flagged: false},
+			{Func: "NilCorrectWay", Flagged: false},
+			{Func: "SafeGetName", Flagged: false},
+		},
+	},
+	{
+		ID:         "pointer_local",
+		Title:      "返回局部变量指针",
+		Problem:    "返回函数内部局部变量的指针，该变量在函数返回后可能被回收",
+		Category:   Pointer,
+		Severity:   Low,
+		GoVersions: AllVersions,
+		Anchor:     "22-返回局部变量指针",
+		Source:     "examples/pointers/pointer_local.go",
+		Wrong:      nil, // 逃逸分析保证了安全，这里没有真正的错误写法
+		Correct:    []string{"SafeExample", "SaferExample", "GetSlice"},
+		Notes: []string{
+			"**注意**：在 Go 中，编译器会进行逃逸分析，通常会自动将变量分配到堆上，" +
+				"所以返回局部变量指针通常是安全的。但理解这个概念很重要。",
+		},
+		Vet: []VetCheck{
+			{Func: "SafeExample", Flagged: false},
+			{Func: "SaferExample", Flagged: false},
+			{Func: "GetSlice", Flagged: false},
+			{Func: "GetArrayPointer", Flagged: false},
+		},
+	},
+	{
+		ID:         "pointer_receiver",
+		Title:      "指针接收者 vs 值接收者",
+		Problem:    "混淆指针接收者和值接收者的使用场景，导致意外的行为",
+		Category:   Pointer,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "23-指针接收者-vs-值接收者",
+		Source:     "examples/pointers/pointer_receiver.go",
+		Wrong:      []string{"Counter.IncrementByValue", "ValueCounter.Increment"},
+		Correct:    []string{"Counter.IncrementByPointer", "PointerCounter.Increment"},
+		Vet: []VetCheck{
+			{Func: "Counter.IncrementByValue", Flagged: true},
+			{Func: "ValueCounter.Increment", Flagged: true},
+			{Func: "Counter.IncrementByPointer", Flagged: false},
+			{Func: "PointerCounter.Increment", Flagged: false},
+		},
+	},
+	{
+		ID:         "slice_pointer",
+		Title:      "切片中的指针问题",
+		Problem:    "切片中存储指针时，容易产生意外的行为",
+		Category:   Pointer,
+		Severity:   Medium,
+		GoVersions: AllVersions, // trap1 只影响 Go 1.22 之前，trap2 与版本无关
+		Anchor:     "24-切片中的指针问题",
+		Source:     "examples/pointers/slice_pointer.go",
+		Wrong:      []string{"SlicePointerTrap1", "SlicePointerTrap2"},
+		Correct:    []string{"SlicePointerCorrectWay", "SlicePointerCorrectWay2", "SlicePointerCorrectWay3"},
+		Notes: []string{
+			"**Go 1.22 的变化**：从 Go 1.22 开始，`SlicePointerTrap1` 中每次迭代的 `&i` 指向不同的变量，" +
+				"会依次打印 0、1、2；只有 go.mod 中的 go 版本低于 1.22 时才会都打印 3。" +
+				"切片扩容导致的问题（`SlicePointerTrap2`）不受影响。",
+		},
+		Vet: []VetCheck{
+			// go.mod 的 Go 版本 >= 1.22，保存循环变量的地址不再是问题，分析器只在低版本下报告
+			{Func: "SlicePointerTrap1", Flagged: false},
+			{Func: "SlicePointerTrap2", Flagged: true, Category: "slice_pointer/stale_element"},
+			{Func: "SlicePointerCorrectWay", Flagged: false},
+			{Func: "SlicePointerCorrectWay2", Flagged: false},
+			{Func: "SlicePointerCorrectWay3", Flagged: false},
+			{Func: "DemonstrateStructSlice", Flagged: false},
+		},
+	},
+
+	// 3. 接口（Interfaces）陷阱
+	{
+		ID:         "interface_nil",
+		Title:      "Nil 接口值",
+		Problem:    "接口值为 nil 但接口类型不为 nil，导致判断错误",
+		Category:   Interface,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "31-nil-接口值",
+		Source:     "examples/interfaces/interface_nil.go",
+		Wrong:      []string{"NilTrap1", "NilTrap2", "ReturnError", "ValidateName"},
+		Correct:    []string{"NilCorrectWay", "ValidateNameCorrectWay"},
+		Vet: []VetCheck{
+			{Func: "ReturnError", Flagged: true},
+			{Func: "ValidateName", Flagged: true},
+			{Func: "ValidateNameCorrectWay", Flagged: false},
+			{Func: "NilCorrectWay", Flagged: false},
+		},
+	},
+	{
+		ID:         "interface_assertion",
+		Title:      "接口类型断言",
+		Problem:    "类型断言失败时未检查 ok 值，导致 panic",
+		Category:   Interface,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "32-接口类型断言",
+		Source:     "examples/interfaces/interface_assertion.go",
+		Wrong:      []string{"AssertionWrongWay"},
+		Correct:    []string{"AssertionCorrectWay", "AssertionCorrectWay2"},
+		Crashes: []Crash{
+			{Func: "AssertionWrongWay", Kind: Panic, Message: "interface conversion: interfaces.Animal is interfaces.Dog, not interfaces.Cat", ExitCode: 2},
+		},
+		Vet: []VetCheck{
+			{Func: "AssertionWrongWay", Flagged: true},
+			{Func: "AssertionCorrectWay", Flagged: false},
+			{Func: "AssertionCorrectWay2", Flagged: false},
+			{Func: "AssertionTwoForms", Flagged: false},
+			{Func: "ProcessAnimal", Flagged: false},
+		},
+	},
+	{
+		ID:         "interface_empty",
+		Title:      "空接口的使用",
+		Problem:    "过度使用空接口 interface{}，失去类型安全",
+		Category:   Interface,
+		Severity:   Low,
+		GoVersions: AllVersions,
+		Anchor:     "33-空接口的使用",
+		Source:     "examples/interfaces/interface_empty.go",
+		Wrong:      []string{"EmptyTrap1"},
+		Correct:    []string{"EmptyCorrectWay", "EmptyCorrectWay2", "SafeTypeAssertion"},
+		Vet: []VetCheck{
+			{Func: "EmptyTrap1", Flagged: true},
+			{Func: "EmptyCorrectWay", Flagged: false},
+			{Func: "EmptyCorrectWay2", Flagged: false},
+			{Func: "SafeTypeAssertion", Flagged: false},
+		},
+	},
+	{
+		ID:         "interface_receiver",
+		Title:      "Interface 接收者问题",
+		Problem:    "接口方法接收者的选择影响接口实现",
+		Category:   Interface,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "34-interface-接收者问题",
+		Source:     "examples/interfaces/interface_receiver.go",
+		Wrong:      []string{"ReceiverTrap1", "ReceiverTrap2"},
+		Correct:    []string{"ReceiverCorrectWay"},
+		Vet: []VetCheck{
+			{Func: "ReceiverTrap1", Flagged: true},
+			// ReceiverTrap2 演示的是指针接收者必须用指针赋值，错误写法只在注释中（编译错误）
+			{Func: "ReceiverTrap2", Flagged: false},
+			{Func: "ReceiverCorrectWay", Flagged: false},
+		},
+	},
+
+	// 4. 通道（Channels）陷阱
+	{
+		ID:         "channel_close",
+		Title:      "未关闭通道导致泄漏",
+		Problem:    "通道未正确关闭，导致接收方永远阻塞",
+		Category:   Channel,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "41-未关闭通道导致泄漏",
+		Source:     "examples/channels/channel_close.go",
+		Wrong:      []string{"CloseWrongWay"},
+		Correct:    []string{"CloseCorrectWay", "CloseCorrectWay2", "Producer"},
+		Output: []OutputRule{
+			{Mode: Sorted, Section: 1},
+			{Mode: Sorted, Section: 2},
+		},
+		Vet: []VetCheck{
+			{Func: "CloseWrongWay", Flagged: true},
+			{Func: "CloseCorrectWay", Flagged: false},
+			{Func: "CloseCorrectWay2", Flagged: false},
+			{Func: "Producer", Flagged: false},
+		},
+	},
+	{
+		ID:         "channel_send_closed",
+		Title:      "向已关闭通道发送数据",
+		Problem:    "向已关闭的通道发送数据会导致 panic",
+		Category:   Channel,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "42-向已关闭通道发送数据",
+		Source:     "examples/channels/channel_send_closed.go",
+		Wrong:      []string{"SendClosedWrongWay", "SendClosedWrongWay2"},
+		Correct:    []string{"SendClosedCorrectWay", "SendClosedCorrectWay2", "SafeSend"},
+		Crashes: []Crash{
+			{Func: "SendClosedWrongWay", Kind: Panic, Message: "send on closed channel", ExitCode: 2},
+			{Func: "SendClosedWrongWay2", Kind: Panic, Message: "send on closed channel", ExitCode: 2},
+		},
+		Output: []OutputRule{
+			{Mode: Sorted, Section: 3},
+		},
+		Vet: []VetCheck{
+			{Func: "SendClosedWrongWay", Flagged: true, Category: "channel_send_closed/send_after_close"},
+			{Func: "SendClosedWrongWay2", Flagged: true, Category: "channel_send_closed/receiver_close"},
+			{Func: "SendClosedCorrectWay", Flagged: false},
+			{Func: "SendClosedCorrectWay2", Flagged: false},
+			{Func: "SafeSend", Flagged: false},
+			{Func: "SendClosedBestPractice", Flagged: false},
+		},
+	},
+	{
+		ID:         "channel_receive_closed",
+		Title:      "从已关闭通道读取",
+		Problem:    "从已关闭的通道读取会立即返回零值，需要检查通道状态",
+		Category:   Channel,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "43-从已关闭通道读取",
+		Source:     "examples/channels/channel_receive_closed.go",
+		Wrong:      []string{"ReceiveClosedTrap1"},
+		Correct:    []string{"ReceiveClosedCorrectWay", "ReceiveClosedCorrectWay2"},
+		Vet: []VetCheck{
+			{Func: "ReceiveClosedTrap1", Flagged: true},
+			{Func: "ReceiveClosedCorrectWay", Flagged: false},
+			{Func: "ReceiveClosedCorrectWay2", Flagged: false},
+		},
+	},
+	{
+		ID:         "channel_select_default",
+		Title:      "Select 的 Default Case",
+		Problem:    "select 语句中的 default case 可能导致非阻塞行为，影响程序逻辑",
+		Category:   Channel,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "44-select-的-default-case",
+		Source:     "examples/channels/channel_select_default.go",
+		Wrong:      []string{"SelectDefaultTrap1", "SelectDefaultTrap2"},
+		Correct:    []string{"SelectDefaultCorrectWay1", "SelectDefaultCorrectWay", "SelectDefaultCorrectWay3", "SelectDefaultCorrectWay4"},
+		Output: []OutputRule{
+			// 占用的 CPU 时间取决于机器和调度
+			{Mode: Scrub, Section: 2, Pattern: `\d+(\.\d+)?(ns|µs|ms|s)`, Replace: "N"},
+			{Mode: Scrub, Section: 3, Pattern: `\d+(\.\d+)?(ns|µs|ms|s)`, Replace: "N"},
+		},
+		Vet: []VetCheck{
+			{Func: "SelectDefaultTrap2", Flagged: true},
+			{Func: "SelectDefaultCorrectWay4", Flagged: false},
+		},
+	},
+
+	// 5. 其他常见陷阱
+	{
+		ID:         "slice_array",
+		Title:      "切片和数组的区别",
+		Problem:    "混淆切片和数组，导致意外的行为",
+		Category:   Other,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "51-切片和数组的区别",
+		Source:     "examples/misc/slice_array.go",
+		Wrong:      []string{"SliceArrayTrap1", "SliceArrayTrap2", "SliceArrayTrap3"},
+		Correct:    []string{"SliceArrayCorrectWay", "SliceArrayCorrectWay2"},
+		Vet: []VetCheck{
+			// 陷阱1、2 演示的是值语义和共享底层数组本身，代码中没有可以判定为错误的写法
+			{Func: "SliceArrayTrap1", Flagged: false},
+			{Func: "SliceArrayTrap2", Flagged: false},
+			{Func: "SliceArrayTrap3", Flagged: true},
+			{Func: "SliceArrayCorrectWay", Flagged: false},
+			{Func: "SliceArrayCorrectWay2", Flagged: false},
+		},
+	},
+	{
+		ID:         "slice_range_modify",
+		Title:      "切片遍历时修改",
+		Problem:    "在遍历切片时修改切片，导致意外的行为",
+		Category:   Other,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "52-切片遍历时修改",
+		Source:     "examples/misc/slice_range_modify.go",
+		Wrong:      []string{"RangeModifyTrap1", "RangeModifyTrap2", "RangeModifyTrap3", "RangeModifyTrap4"},
+		Correct:    []string{"RangeModifyCorrectWay", "RangeModifyCorrectWay2", "RangeModifyCorrectWay3", "RangeModifyCorrectWay4"},
+		Vet: []VetCheck{
+			{Func: "RangeModifyTrap1", Flagged: true, Category: "slice_range_modify/value_copy"},
+			{Func: "RangeModifyTrap2", Flagged: true, Category: "slice_range_modify/delete"},
+			// 通过子切片修改共享的底层数组是合法的写法，分析器不报告
+			{Func: "RangeModifyTrap3", Flagged: false},
+			{Func: "RangeModifyTrap4", Flagged: true, Category: "slice_range_modify/append"},
+			{Func: "RangeModifyCorrectWay", Flagged: false},
+			{Func: "RangeModifyCorrectWay2", Flagged: false},
+			{Func: "RangeModifyCorrectWay3", Flagged: false},
+			{Func: "RangeModifyCorrectWay4", Flagged: false},
+		},
+	},
+	{
+		ID:         "map_concurrent",
+		Title:      "Map 的并发读写",
+		Problem:    "多个 goroutine 同时读写 map 会导致 panic",
+		Category:   Other,
+		Severity:   Critical,
+		GoVersions: AllVersions,
+		Anchor:     "53-map-的并发读写",
+		Source:     "examples/misc/map_concurrent.go",
+		Wrong:      []string{"MapConcurrentWrongWay"},
+		Correct:    []string{"MapConcurrentCorrectWay1", "MapConcurrentCorrectWay2", "MapConcurrentCorrectWay3", "SafeCounter", "DemonstrateCounter"},
+		Notes: []string{
+			"**注意**：并发读写 map 触发的是运行时的 fatal error，不能被 recover。" +
+				"`go run ./cmd/gotrap race map_concurrent` 会用竞态检测器指出发生竞争的读写位置。",
+		},
+		Races: []RaceCheck{
+			{Func: "MapConcurrentWrongWay", Racy: true},
+			{Func: "MapConcurrentCorrectWay1", Racy: false},
+			{Func: "MapConcurrentCorrectWay2", Racy: false},
+			{Func: "MapConcurrentCorrectWay3", Racy: false},
+			{Func: "DemonstrateCounter", Racy: false},
+		},
+		Output: []OutputRule{
+			// 读到的值和读取次数取决于读写 goroutine 的调度
+			{Mode: Scrub, Section: 2, Pattern: `\d+`, Replace: "N"},
+			{Mode: Set, Section: 2},
+			{Mode: Scrub, Section: 3, Pattern: `\d+`, Replace: "N"},
+			{Mode: Set, Section: 3},
+		},
+		Vet: []VetCheck{
+			{Func: "MapConcurrentWrongWay", Flagged: true},
+			{Func: "MapConcurrentCorrectWay1", Flagged: false},
+			{Func: "MapConcurrentCorrectWay2", Flagged: false},
+			{Func: "MapConcurrentCorrectWay3", Flagged: false},
+			{Func: "DemonstrateCounter", Flagged: false},
+		},
+	},
+	{
+		ID:         "map_nil_write",
+		Title:      "nil map 写入",
+		Problem:    "向 nil map 写入数据会导致 panic",
+		Category:   Other,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "54-nil-map-写入",
+		Source:     "examples/misc/map_nil_write.go",
+		Wrong:      []string{"NilMapTrap1", "NilMapTrap2", "NilMapTrap3", "NilMapTrap4"},
+		Correct:    []string{"NilMapCorrectWay", "NilMapCorrectWay2", "ProcessMap"},
+		Crashes: []Crash{
+			{Func: "NilMapTrap1", Kind: Panic, Message: "assignment to entry in nil map", ExitCode: 2},
+			{Func: "NilMapTrap3", Kind: Panic, Message: "assignment to entry in nil map", ExitCode: 2},
+			{Func: "NilMapTrap4", Kind: Panic, Message: "assignment to entry in nil map", ExitCode: 2},
+		},
+		Vet: []VetCheck{
+			{Func: "NilMapTrap1", Flagged: true},
+			// 读取和删除 nil map 是安全的
+			{Func: "NilMapTrap2", Flagged: false},
+			{Func: "NilMapTrap3", Flagged: true},
+			// nil 来自 NilMapTrap4，写入发生在 recordVisit 中
+			{Func: "recordVisit", Flagged: true},
+			{Func: "NilMapCorrectWay", Flagged: false},
+			{Func: "NilMapCorrectWay2", Flagged: false},
+			{Func: "ProcessMap", Flagged: false},
+		},
+	},
+	{
+		ID:         "map_key_type",
+		Title:      "Map 键类型限制",
+		Problem:    "map 的键类型必须是可比较的类型",
+		Category:   Other,
+		Severity:   Low, // 编译器会直接报错
+		GoVersions: AllVersions,
+		Anchor:     "55-map-键类型限制",
+		Source:     "examples/misc/map_key_type.go",
+		Wrong:      []string{"MapKeyTrap1", "MapKeyTrap2"},
+		Correct:    []string{"MapKeyCorrectWay", "MapKeyCorrectWay2", "MapKeyCorrectWay3"},
+		Vet: []VetCheck{
+			// 错误写法都是编译错误，只写在注释中
+			{Func: "MapKeyTrap1", Flagged: false},
+			{Func: "MapKeyTrap2", Flagged: false},
+			{Func: "MapKeyCorrectWay", Flagged: false},
+			{Func: "MapKeyCorrectWay2", Flagged: false},
+			{Func: "MapKeyCorrectWay3", Flagged: false},
+		},
+	},
+	{
+		ID:         "defer_order",
+		Title:      "Defer 的执行顺序",
+		Problem:    "defer 语句的执行顺序和参数求值时机容易混淆",
+		Category:   Other,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "56-defer-的执行顺序",
+		Source:     "examples/misc/defer_order.go",
+		Wrong:      []string{"DeferTrap1", "DeferTrap2", "DeferTrap3"},
+		Correct:    []string{"DeferCorrectWay", "DeferCorrectWay2"},
+		Notes: []string{
+			"**注意**：defer 的执行顺序是 LIFO（后进先出），defer 可以修改命名返回值。",
+		},
+		Vet: []VetCheck{
+			{Func: "DeferTrap1", Flagged: true},
+			// 后进先出的执行顺序是语言规定，不是可以检查的错误
+			{Func: "DeferTrap2", Flagged: false},
+			{Func: "ReturnValue1", Flagged: false},
+			{Func: "ReturnValue2", Flagged: true},
+			{Func: "DeferCorrectWay", Flagged: false},
+			{Func: "DeferCorrectWay2", Flagged: false},
+		},
+	},
+	{
+		ID:         "error_handling",
+		Title:      "错误处理",
+		Problem:    "忽略错误或错误处理不当，导致程序行为异常",
+		Category:   Other,
+		Severity:   High,
+		GoVersions: AllVersions,
+		Anchor:     "57-错误处理",
+		Source:     "examples/misc/error_handling.go",
+		Wrong:      []string{"ErrorHandlingTrap1", "ErrorHandlingTrap2", "ErrorHandlingTrap3"},
+		Correct:    []string{"ErrorHandlingCorrectWay", "ErrorHandlingCorrectWay2", "ErrorHandlingCorrectWay3"},
+		Vet: []VetCheck{
+			{Func: "ErrorHandlingTrap1", Flagged: true, Category: "error_handling/defer_before_check"},
+			{Func: "ErrorHandlingTrap2", Flagged: true, Category: "error_handling/equal_compare"},
+			{Func: "ProcessFile", Flagged: true, Category: "error_handling/wrap_verb"},
+			{Func: "ErrorHandlingCorrectWay", Flagged: false},
+			{Func: "ErrorHandlingCorrectWay2", Flagged: false},
+			{Func: "ProcessFile2", Flagged: false},
+		},
+	},
+	{
+		ID:         "variable_shadowing",
+		Title:      "变量遮蔽（Variable Shadowing）",
+		Problem:    "内部作用域的变量遮蔽外部作用域的变量，导致意外的行为",
+		Category:   Other,
+		Severity:   Medium,
+		GoVersions: AllVersions,
+		Anchor:     "58-变量遮蔽variable-shadowing",
+		Source:     "examples/misc/variable_shadowing.go",
+		Wrong:      []string{"ShadowingTrap1", "ShadowingTrap2", "ShadowingTrap3"},
+		Correct:    []string{"ShadowingCorrectWay", "ShadowingCorrectWay2", "ShadowingCorrectWay3"},
+		Vet: []VetCheck{
+			{Func: "ShadowingTrap1", Flagged: true},
+			{Func: "ShadowingTrap2", Flagged: true},
+			{Func: "ShadowingTrap3", Flagged: true},
+			{Func: "ShadowingCorrectWay", Flagged: false},
+			{Func: "ShadowingCorrectWay2", Flagged: false},
+			{Func: "ShadowingCorrectWay3", Flagged: false},
+			// i := i 遮蔽的循环变量在循环结束后不再使用
+			{Func: "DemonstrateLoop", Flagged: false},
+		},
+	},
+	{
+		ID:         "performance_pitfalls",
+		Title:      "性能问题",
+		Problem:    "常见的性能陷阱导致程序运行缓慢",
+		Category:   Other,
+		Severity:   Low,
+		GoVersions: AllVersions,
+		Anchor:     "59-性能问题",
+		Source:     "examples/misc/performance_pitfalls.go",
+		Wrong:      []string{"PerfTrap1", "PerfTrap2", "PerfTrap3", "PerfTrap4", "PerfTrap5", "PerfTrap6"},
+		Correct:    []string{"PerfCorrectWay", "PerfCorrectWay2", "PerfCorrectWay3", "PerfCorrectWay4", "PerfCorrectWay6"},
+		Benches: []BenchPair{
+			{Wrong: "PerfTrap1", Correct: "PerfCorrectWay", Metric: NsPerOp, MinRatio: 2},
+			{Wrong: "PerfTrap2", Correct: "PerfCorrectWay2", Metric: AllocsPerOp, MinRatio: 5},
+			{Wrong: "PerfTrap3", Correct: "PerfCorrectWay3", Metric: BytesPerOp, MinRatio: 100},
+			{Wrong: "PerfTrap4", Correct: "PerfCorrectWay4", Metric: NsPerOp, MinRatio: 5},
+			{Wrong: "PerfTrap6", Correct: "PerfCorrectWay6", Metric: NsPerOp, MinRatio: 1.3},
+		},
+		Vet: []VetCheck{
+			{Func: "PerfTrap1", Flagged: true, Category: "performance_pitfalls/string_concat"},
+			{Func: "PerfTrap2", Flagged: true, Category: "performance_pitfalls/append_prealloc"},
+			{Func: "PerfTrap5", Flagged: true, Category: "performance_pitfalls/sprintf_write"},
+			{Func: "PerfCorrectWay", Flagged: false},
+			{Func: "PerfCorrectWay2", Flagged: false},
+		},
+	},
+}